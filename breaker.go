@@ -0,0 +1,232 @@
+package swiftx
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"sync"
+	"time"
+)
+
+// ErrCircuitOpen 熔断器已打开，请求未发送
+var ErrCircuitOpen = errors.New("熔断器已打开")
+
+// CircuitOpenError 熔断器已打开的错误，errors.Is(err, ErrCircuitOpen) 为 true
+type CircuitOpenError struct {
+	Endpoint string    // 接口名称
+	RetryAt  time.Time // 下次尝试探测的时间
+	Cause    error     // 探测失败时的错误
+}
+
+func (e *CircuitOpenError) Error() string {
+	msg := fmt.Sprintf("%s 接口熔断中，%s 后重试", e.Endpoint, e.RetryAt.Format(time.TimeOnly))
+	if e.Cause != nil {
+		msg += "（探测失败：" + e.Cause.Error() + "）"
+	}
+	return msg
+}
+
+func (e *CircuitOpenError) Is(target error) bool {
+	return target == ErrCircuitOpen
+}
+
+func (e *CircuitOpenError) Unwrap() error {
+	return e.Cause
+}
+
+// CircuitState 熔断器状态
+type CircuitState int
+
+const (
+	CircuitClosed   CircuitState = iota // 关闭（正常请求）
+	CircuitOpen                         // 打开（请求直接失败）
+	CircuitHalfOpen                     // 半开（正在通过 pingPong 接口探测）
+)
+
+func (s CircuitState) String() string {
+	switch s {
+	case CircuitClosed:
+		return "closed"
+	case CircuitOpen:
+		return "open"
+	case CircuitHalfOpen:
+		return "half-open"
+	}
+	return fmt.Sprintf("CircuitState(%d)", int(s))
+}
+
+// CircuitBreakerOptions 熔断器配置
+type CircuitBreakerOptions struct {
+	FailureThreshold int                                          // 连续失败多少次后打开熔断器，默认为 5
+	OpenTimeout      time.Duration                                // 熔断器打开后多久开始探测，默认为 30 秒
+	IsFailure        func(call *Call, err error) bool             // 判断调用是否失败，默认网络错误、超时、5xx 和 429 为失败
+	OnStateChange    func(endpoint string, from, to CircuitState) // 状态变化时调用，可用于切换到备用承运商
+}
+
+// WithCircuitBreaker 按接口启用熔断器
+//
+// 某个接口连续失败达到 FailureThreshold 次后，该接口的调用直接返回 *CircuitOpenError。
+// OpenTimeout 之后的第一次调用先通过 pingService.Pong 探测，探测成功后关闭熔断器并继续调用，否则熔断器重新打开。
+func WithCircuitBreaker(opts CircuitBreakerOptions) Option {
+	return func(c *Client) {
+		c.breaker = newCircuitBreaker(opts)
+	}
+}
+
+// CircuitState 返回接口的熔断器状态，未启用熔断器时总是返回 CircuitClosed
+func (c *Client) CircuitState(endpoint string) CircuitState {
+	if c.breaker == nil {
+		return CircuitClosed
+	}
+	c.breaker.mu.Lock()
+	defer c.breaker.mu.Unlock()
+	if circuit, ok := c.breaker.circuits[endpoint]; ok {
+		return circuit.state
+	}
+	return CircuitClosed
+}
+
+type circuit struct {
+	state    CircuitState
+	failures int       // 连续失败次数
+	openedAt time.Time // 打开时间
+}
+
+type circuitBreaker struct {
+	opts     CircuitBreakerOptions
+	now      func() time.Time
+	mu       sync.Mutex
+	circuits map[string]*circuit
+}
+
+func newCircuitBreaker(opts CircuitBreakerOptions) *circuitBreaker {
+	if opts.FailureThreshold <= 0 {
+		opts.FailureThreshold = 5
+	}
+	if opts.OpenTimeout <= 0 {
+		opts.OpenTimeout = 30 * time.Second
+	}
+	if opts.IsFailure == nil {
+		opts.IsFailure = func(call *Call, err error) bool {
+			switch call.ErrorCategory(err) {
+			case ErrorCategoryNetwork, ErrorCategoryTimeout, ErrorCategoryServer, ErrorCategoryRateLimit:
+				return true
+			}
+			return false
+		}
+	}
+	return &circuitBreaker{
+		opts:     opts,
+		now:      time.Now,
+		circuits: make(map[string]*circuit),
+	}
+}
+
+// transition 修改状态并返回状态变化的回调，需要在持有锁时调用，在释放锁后调用返回的回调
+func (b *circuitBreaker) transition(endpoint string, c *circuit, to CircuitState) func() {
+	from := c.state
+	c.state = to
+	if to == CircuitOpen {
+		c.openedAt = b.now()
+	}
+	if to == CircuitClosed {
+		c.failures = 0
+	}
+	if from == to || b.opts.OnStateChange == nil {
+		return func() {}
+	}
+	return func() { b.opts.OnStateChange(endpoint, from, to) }
+}
+
+// allow 检查是否允许调用，熔断器打开超过 OpenTimeout 时使用 probe 探测
+func (b *circuitBreaker) allow(ctx context.Context, endpoint string, probe func(ctx context.Context) error) error {
+	b.mu.Lock()
+	c, ok := b.circuits[endpoint]
+	if !ok {
+		c = &circuit{}
+		b.circuits[endpoint] = c
+	}
+	switch c.state {
+	case CircuitClosed:
+		b.mu.Unlock()
+		return nil
+	case CircuitHalfOpen:
+		// 其他调用正在探测
+		b.mu.Unlock()
+		return &CircuitOpenError{Endpoint: endpoint, RetryAt: b.now()}
+	}
+	retryAt := c.openedAt.Add(b.opts.OpenTimeout)
+	if b.now().Before(retryAt) {
+		b.mu.Unlock()
+		return &CircuitOpenError{Endpoint: endpoint, RetryAt: retryAt}
+	}
+	notify := b.transition(endpoint, c, CircuitHalfOpen)
+	b.mu.Unlock()
+	notify()
+
+	err := probe(ctx)
+
+	b.mu.Lock()
+	if err != nil {
+		notify = b.transition(endpoint, c, CircuitOpen)
+		retryAt = c.openedAt.Add(b.opts.OpenTimeout)
+	} else {
+		notify = b.transition(endpoint, c, CircuitClosed)
+	}
+	b.mu.Unlock()
+	notify()
+	if err != nil {
+		return &CircuitOpenError{Endpoint: endpoint, RetryAt: retryAt, Cause: err}
+	}
+	return nil
+}
+
+// record 记录调用结果
+func (b *circuitBreaker) record(endpoint string, failed bool) {
+	b.mu.Lock()
+	c := b.circuits[endpoint]
+	notify := func() {}
+	if failed {
+		c.failures++
+		if c.state == CircuitClosed && c.failures >= b.opts.FailureThreshold {
+			notify = b.transition(endpoint, c, CircuitOpen)
+		}
+	} else {
+		c.failures = 0
+	}
+	b.mu.Unlock()
+	notify()
+}
+
+func (b *circuitBreaker) middleware() Middleware {
+	return func(next Handler) Handler {
+		// 探测请求不经过熔断器
+		ping := pingService{handler: next}
+		probe := func(ctx context.Context) error {
+			n := rand.IntN(1 << 30)
+			echo, err := ping.Pong(ctx, n)
+			if err != nil {
+				return err
+			}
+			if echo != n {
+				return fmt.Errorf("pingPong 返回值 %d 与请求值 %d 不一致", echo, n)
+			}
+			return nil
+		}
+		return HandlerFunc(func(ctx context.Context, call *Call) error {
+			if call.DryRun {
+				// 试运行的调用不会发送到 SwiftX，不影响熔断状态
+				return next.Handle(ctx, call)
+			}
+			if err := b.allow(ctx, call.Endpoint, probe); err != nil {
+				return err
+			}
+			err := next.Handle(ctx, call)
+			if !errors.Is(err, context.Canceled) {
+				b.record(call.Endpoint, b.opts.IsFailure(call, err))
+			}
+			return err
+		})
+	}
+}
@@ -0,0 +1,97 @@
+package swiftx
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClient_CircuitBreaker(t *testing.T) {
+	var down atomic.Bool
+	var cancelRequests, pingRequests atomic.Int32
+	var changes []string
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasSuffix(r.URL.Path, EndpointPingPong) {
+			pingRequests.Add(1)
+		} else {
+			cancelRequests.Add(1)
+		}
+		if down.Load() {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		if strings.HasSuffix(r.URL.Path, EndpointPingPong) {
+			fmt.Fprint(w, r.URL.Query().Get("i"))
+			return
+		}
+		fmt.Fprint(w, `{"success":false,"message":"订单已揽收"}`)
+	}, WithCircuitBreaker(CircuitBreakerOptions{
+		FailureThreshold: 2,
+		OpenTimeout:      time.Minute,
+		OnStateChange: func(endpoint string, from, to CircuitState) {
+			changes = append(changes, fmt.Sprintf("%s:%s->%s", endpoint, from, to))
+		},
+	}))
+	now := time.Now()
+	c.breaker.now = func() time.Time { return now }
+
+	// 业务错误不计入失败次数
+	for i := 0; i < 3; i++ {
+		_, err := c.Services.Order.Cancel(ctx, "SWX1")
+		assert.EqualError(t, err, "订单已揽收")
+	}
+	assert.Equal(t, CircuitClosed, c.CircuitState(EndpointCancelOrder))
+
+	down.Store(true)
+	for i := 0; i < 2; i++ {
+		_, err := c.Services.Order.Cancel(ctx, "SWX1")
+		assert.False(t, errors.Is(err, ErrCircuitOpen))
+	}
+	assert.Equal(t, CircuitOpen, c.CircuitState(EndpointCancelOrder))
+	assert.Equal(t, CircuitClosed, c.CircuitState(EndpointPingPong))
+
+	// 熔断器打开时请求不会发送
+	cancelRequests.Store(0)
+	_, err := c.Services.Order.Cancel(ctx, "SWX1")
+	var openErr *CircuitOpenError
+	if assert.True(t, errors.As(err, &openErr)) {
+		assert.Equal(t, EndpointCancelOrder, openErr.Endpoint)
+		assert.Equal(t, now.Add(time.Minute), openErr.RetryAt)
+	}
+	assert.Equal(t, int32(0), cancelRequests.Load())
+	assert.Equal(t, int32(0), pingRequests.Load())
+
+	// 探测失败，熔断器重新打开
+	now = now.Add(time.Minute)
+	_, err = c.Services.Order.Cancel(ctx, "SWX1")
+	assert.True(t, errors.Is(err, ErrCircuitOpen))
+	assert.True(t, errors.As(err, &openErr))
+	assert.NotNil(t, openErr.Cause)
+	assert.Equal(t, int32(1), pingRequests.Load())
+	assert.Equal(t, int32(0), cancelRequests.Load())
+	assert.Equal(t, CircuitOpen, c.CircuitState(EndpointCancelOrder))
+
+	// 探测成功，熔断器关闭并继续请求
+	down.Store(false)
+	now = now.Add(time.Minute)
+	_, err = c.Services.Order.Cancel(ctx, "SWX1")
+	assert.EqualError(t, err, "订单已揽收")
+	assert.Equal(t, int32(2), pingRequests.Load())
+	assert.Equal(t, int32(1), cancelRequests.Load())
+	assert.Equal(t, CircuitClosed, c.CircuitState(EndpointCancelOrder))
+
+	assert.Equal(t, []string{
+		"cancelOrder:closed->open",
+		"cancelOrder:open->half-open",
+		"cancelOrder:half-open->open",
+		"cancelOrder:open->half-open",
+		"cancelOrder:half-open->closed",
+	}, changes)
+}
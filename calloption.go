@@ -0,0 +1,137 @@
+package swiftx
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// HeaderRequestID 调用方指定的请求 ID 的请求头
+const HeaderRequestID = "X-Request-Id"
+
+// CallOption 单次调用的选项
+//
+// Pong、Create、Cancel 可以直接传入 CallOption；Tracking、Postage 的订单号参数是可变参数，使用 ContextWithCallOptions 通过 ctx 传入。
+type CallOption func(o *callOptions)
+
+type callOptions struct {
+	timeout   time.Duration
+	header    http.Header
+	requestID string
+	debug     *bool
+}
+
+// CallTimeout 设置本次调用的超时时间（包括限流等待和重试），覆盖 Config.Timeout，可以比 Config.Timeout 更长
+func CallTimeout(d time.Duration) CallOption {
+	return func(o *callOptions) {
+		o.timeout = d
+	}
+}
+
+// CallHeader 添加本次调用的请求头
+func CallHeader(key, value string) CallOption {
+	return func(o *callOptions) {
+		if o.header == nil {
+			o.header = http.Header{}
+		}
+		o.header.Add(key, value)
+	}
+}
+
+// CallRequestID 设置本次调用的请求 ID（关联 ID），通过 X-Request-Id 请求头发送，会记录到请求日志中，
+// 调用失败时返回的错误为包含请求 ID 的 *RequestError
+func CallRequestID(id string) CallOption {
+	return func(o *callOptions) {
+		o.requestID = id
+	}
+}
+
+// CallDebug 设置本次调用是否输出（脱敏后的）请求和响应内容，覆盖 Config.Debug
+func CallDebug(enabled bool) CallOption {
+	return func(o *callOptions) {
+		o.debug = &enabled
+	}
+}
+
+type callOptionsContextKey struct{}
+
+// ContextWithCallOptions 返回包含 CallOption 的 ctx，使用该 ctx 的调用都会应用这些选项，直接传入的 CallOption 优先
+func ContextWithCallOptions(ctx context.Context, opts ...CallOption) context.Context {
+	parent, _ := ctx.Value(callOptionsContextKey{}).([]CallOption)
+	merged := make([]CallOption, 0, len(parent)+len(opts))
+	merged = append(merged, parent...)
+	merged = append(merged, opts...)
+	return context.WithValue(ctx, callOptionsContextKey{}, merged)
+}
+
+// RequestError 包含请求 ID 的调用错误（使用 CallRequestID 时返回）
+type RequestError struct {
+	RequestID string // 请求 ID
+	Endpoint  string // 接口名称
+	Err       error
+}
+
+func (e *RequestError) Error() string {
+	return fmt.Sprintf("%s（请求 ID：%s）", e.Err.Error(), e.RequestID)
+}
+
+func (e *RequestError) Unwrap() error {
+	return e.Err
+}
+
+// RequestIDFromError 返回错误中的请求 ID
+func RequestIDFromError(err error) (string, bool) {
+	var requestErr *RequestError
+	if errors.As(err, &requestErr) {
+		return requestErr.RequestID, true
+	}
+	return "", false
+}
+
+// handle 应用 ctx 和 opts 中的 CallOption 后执行调用
+func (s service) handle(ctx context.Context, call *Call, opts []CallOption) error {
+	var o callOptions
+	if ctxOpts, ok := ctx.Value(callOptionsContextKey{}).([]CallOption); ok {
+		for _, opt := range ctxOpts {
+			opt(&o)
+		}
+	}
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	// 超时通过 ctx 控制，未设置 CallTimeout 时使用 Config.Timeout
+	timeout := o.timeout
+	if timeout <= 0 && s.config != nil {
+		timeout = time.Duration(s.config.Timeout) * time.Second
+	}
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+	if len(o.header) > 0 || o.requestID != "" {
+		if call.Header == nil {
+			call.Header = http.Header{}
+		}
+		for key, values := range o.header {
+			for _, value := range values {
+				call.Header.Add(key, value)
+			}
+		}
+		if o.requestID != "" {
+			call.Header.Set(HeaderRequestID, o.requestID)
+		}
+	}
+	call.RequestID = o.requestID
+	call.Debug = o.debug
+	call.DryRun = s.dryRun && isDryRunEndpoint(call.Endpoint)
+
+	err := s.handler.Handle(ctx, call)
+	if err != nil && o.requestID != "" {
+		return &RequestError{RequestID: o.requestID, Endpoint: call.Endpoint, Err: err}
+	}
+	return err
+}
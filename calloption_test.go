@@ -0,0 +1,107 @@
+package swiftx
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hiscaler/swiftx-go/config"
+	"github.com/hiscaler/swiftx-go/entity"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCallOptions(t *testing.T) {
+	var received http.Header
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if !strings.HasSuffix(r.URL.Path, EndpointBatchGetTracking) {
+			received = r.Header.Clone()
+		}
+		switch {
+		case strings.HasSuffix(r.URL.Path, EndpointCancelOrder):
+			fmt.Fprint(w, `{"success":false,"message":"订单已揽收"}`)
+		case strings.HasSuffix(r.URL.Path, EndpointBatchGetTracking):
+			time.Sleep(100 * time.Millisecond)
+			fmt.Fprint(w, `[]`)
+		default:
+			fmt.Fprint(w, r.URL.Query().Get("i"))
+		}
+	}))
+	defer srv.Close()
+
+	var buf bytes.Buffer
+	c := NewClient(config.Config{
+		Env:       entity.Test,
+		Timeout:   5,
+		AppKey:    "test-app-key",
+		AppSecret: "test-app-secret",
+		Logger:    slog.New(slog.NewTextHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug})),
+	}, WithRequestLog(RequestLogOptions{}))
+	c.httpClient.SetBaseURL(srv.URL)
+
+	// 请求头和请求 ID
+	n, err := c.Services.Ping.Pong(ctx, 1, CallHeader("X-Tenant", "a"), CallRequestID("req-1"))
+	assert.NoError(t, err)
+	assert.Equal(t, 1, n)
+	assert.Equal(t, "a", received.Get("X-Tenant"))
+	assert.Equal(t, "req-1", received.Get(HeaderRequestID))
+	assert.Contains(t, buf.String(), "request_id=req-1")
+	assert.NotContains(t, buf.String(), "~~~ REQUEST ~~~")
+
+	// 调用失败时返回请求 ID
+	_, err = c.Services.Order.Cancel(ctx, "SWX1", CallRequestID("req-2"))
+	id, ok := RequestIDFromError(err)
+	assert.True(t, ok)
+	assert.Equal(t, "req-2", id)
+	var resultErr *ResultError
+	assert.True(t, errors.As(err, &resultErr))
+	assert.EqualError(t, err, "订单已揽收（请求 ID：req-2）")
+	_, err = c.Services.Order.Cancel(ctx, "SWX1")
+	_, ok = RequestIDFromError(err)
+	assert.False(t, ok)
+
+	// 通过 ctx 传入，直接传入的选项优先
+	callCtx := ContextWithCallOptions(ctx, CallRequestID("req-3"), CallHeader("X-Tenant", "b"))
+	_, err = c.Services.Ping.Pong(callCtx, 2, CallRequestID("req-4"))
+	assert.NoError(t, err)
+	assert.Equal(t, "req-4", received.Get(HeaderRequestID))
+	assert.Equal(t, "b", received.Get("X-Tenant"))
+
+	// 超时
+	_, err = c.Services.Order.Tracking(ContextWithCallOptions(ctx, CallTimeout(10*time.Millisecond)), "SWX1")
+	assert.True(t, errors.Is(err, context.DeadlineExceeded))
+	_, err = c.Services.Order.Tracking(ctx, "SWX1")
+	assert.NoError(t, err)
+
+	// 调试输出
+	buf.Reset()
+	_, err = c.Services.Ping.Pong(ctx, 3, CallDebug(true))
+	assert.NoError(t, err)
+	assert.Contains(t, buf.String(), "~~~ REQUEST ~~~")
+}
+
+func TestCallTimeout_OverridesConfigTimeout(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		time.Sleep(1200 * time.Millisecond)
+		fmt.Fprint(w, `[]`)
+	}))
+	defer srv.Close()
+
+	c := NewClient(config.Config{Env: entity.Test, Timeout: 1, AppKey: "test-app-key", AppSecret: "test-app-secret"})
+	c.httpClient.SetBaseURL(srv.URL)
+
+	// 默认使用 Config.Timeout
+	_, err := c.Services.Order.Tracking(ctx, "SWX1")
+	assert.True(t, errors.Is(err, context.DeadlineExceeded))
+	// CallTimeout 可以比 Config.Timeout 更长
+	_, err = c.Services.Order.Tracking(ContextWithCallOptions(ctx, CallTimeout(3*time.Second)), "SWX1")
+	assert.NoError(t, err)
+}
@@ -1,18 +1,14 @@
 package swiftx
 
 import (
-	"crypto/hmac"
-	"crypto/rand"
-	"crypto/sha256"
-	"encoding/hex"
+	"context"
+	"crypto/tls"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"log/slog"
 	"net/http"
-	"net/url"
 	"sort"
-	"strconv"
 	"strings"
 	"time"
 
@@ -34,60 +30,27 @@ const (
 )
 
 type Client struct {
-	config     *config.Config // 配置
-	logger     *slog.Logger   // Logger
-	httpClient *resty.Client  // Resty Client
-	Services   services       // API Services
+	config      *config.Config      // 配置
+	logger      *slog.Logger        // Logger
+	credentials *credentialRotation // 凭证
+	signer      Signer              // 签名
+	clock       *serverClock        // 服务器时钟误差
+	compensate  bool                // 是否校正签名时间
+	rateLimiter RateLimiter         // 限流器
+	transport   http.RoundTripper   // HTTP Transport
+	tlsConfig   *tls.Config         // TLS 配置
+	middlewares []Middleware        // 中间件
+	redaction   Redaction           // 日志脱敏配置
+	requestLog  *RequestLogOptions  // 请求日志配置
+	harRecorder *HARRecorder        // HAR 记录器
+	breaker     *circuitBreaker     // 熔断器
+	dryRun      bool                // 是否为试运行模式
+	limits      ServiceLimits       // 包裹重量和尺寸限制
+	httpClient  *resty.Client       // Resty Client
+	Services    services            // API Services
 }
 
-type signature struct {
-	timestamp     int64
-	nonce         string
-	contentSHA256 string
-	signature     string
-}
-
-// buildSignature 构建签名
-// 签名格式：{app_key}\n{timestamp}\n{nonce}\n{content_sha256}\n{http_method}\n{path}\n{query_string}
-func buildSignature(appKey, appSecret, httpMethod, apiPath, queryString string, requestBody any) (signature, error) {
-	timestamp := time.Now().Unix()
-	nonceBytes := make([]byte, 16)
-	if _, err := rand.Read(nonceBytes); err != nil {
-		return signature{}, fmt.Errorf("failed to generate random nonce: " + err.Error())
-	}
-	nonce := hex.EncodeToString(nonceBytes)
-	// 计算 requestBody 的 SHA256 哈希值
-	var bodyBytes []byte
-	if requestBody != nil {
-		bodyBytes, _ = json.Marshal(requestBody)
-	}
-	hasher := sha256.New()
-	hasher.Write(bodyBytes)
-	contentSHA256 := hex.EncodeToString(hasher.Sum(nil))
-	// 构建待签名字符串
-	stringToSign := fmt.Sprintf("%s\n%d\n%s\n%s\n%s\n%s\n%s",
-		appKey,
-		timestamp,
-		nonce,
-		contentSHA256,
-		httpMethod,
-		apiPath,
-		queryString,
-	)
-	// 计算签名
-	h := hmac.New(sha256.New, []byte(appSecret))
-	h.Write([]byte(stringToSign))
-	sign := hex.EncodeToString(h.Sum(nil))
-
-	return signature{
-		timestamp:     timestamp,
-		nonce:         nonce,
-		contentSHA256: contentSHA256,
-		signature:     sign,
-	}, nil
-}
-
-func NewClient(cfg config.Config) *Client {
+func NewClient(cfg config.Config, opts ...Option) *Client {
 	l := createLogger()
 	debug := cfg.Debug
 	if cfg.Logger != nil {
@@ -95,9 +58,27 @@ func NewClient(cfg config.Config) *Client {
 	}
 
 	swiftxClient := &Client{
-		config: &cfg,
-		logger: l.l,
+		config:      &cfg,
+		logger:      l.l,
+		credentials: &credentialRotation{},
+		clock:       &serverClock{},
+		compensate:  true,
+	}
+	for _, opt := range opts {
+		opt(swiftxClient)
+	}
+	credentials := swiftxClient.credentials
+	if credentials.provider == nil {
+		if cfg.AppSecretFile != "" {
+			credentials.provider = NewFileCredentials(cfg.AppKey, cfg.AppSecretFile, 0)
+		} else {
+			credentials.provider = StaticCredentials{AppKey: cfg.AppKey, AppSecret: cfg.AppSecret}
+		}
+	}
+	credentials.onRotate = func(from, to Credential) {
+		l.l.Info("credential rotated", "from_app_key", from.AppKey, "to_app_key", to.AppKey)
 	}
+
 	baseUrl := ProdBaseUrl
 	if cfg.Env != entity.Prod {
 		baseUrl = TestBaseUrl
@@ -109,47 +90,87 @@ func NewClient(cfg config.Config) *Client {
 			"Content-Type": "application/json",
 			"Accept":       "application/json",
 			"User-Agent":   userAgent,
-			"X-App-Key":    cfg.AppKey,
 		}).
-		SetTimeout(time.Duration(cfg.Timeout) * time.Second).
 		OnBeforeRequest(func(client *resty.Client, request *resty.Request) error {
-			u, err := url.Parse(request.URL)
-			if err != nil {
-				l.l.Error("request url parse", "error", err)
-				return err
+			if swiftxClient.rateLimiter != nil {
+				if err := swiftxClient.rateLimiter.Wait(request.Context()); err != nil {
+					return err
+				}
 			}
-			sign, err := buildSignature(cfg.AppKey, cfg.AppSecret, request.Method, "/api/v2/openapi"+u.Path, request.QueryParam.Encode(), request.Body)
+			credential, err := credentials.credential(request.Context())
 			if err != nil {
-				l.l.Error("signature build", "error", err)
+				l.l.Error("credential", "error", err)
 				return err
 			}
-
-			request.SetHeaders(map[string]string{
-				"X-Timestamp":      strconv.Itoa(int(sign.timestamp)),
-				"X-Nonce":          sign.nonce,
-				"X-Content-SHA256": sign.contentSHA256,
-				"X-Signature":      sign.signature,
-			})
-
+			// 签名在请求发送前（signingTransport）基于实际发送的内容计算，
+			// 凭证同时用于身份验证失败时判断是否需要切换到新凭证
+			request.SetContext(context.WithValue(request.Context(), credentialContextKey{}, credential))
 			return nil
 		}).
 		SetRetryCount(2).
 		SetRetryWaitTime(1 * time.Second).
 		SetRetryMaxWaitTime(2 * time.Second).
 		AddRetryCondition(func(response *resty.Response, err error) bool {
-			if response != nil && response.StatusCode() == http.StatusTooManyRequests {
+			if response == nil {
+				return false
+			}
+			switch response.StatusCode() {
+			case http.StatusTooManyRequests:
 				return true
+			case http.StatusUnauthorized:
+				// 时钟误差导致的身份验证失败，校正签名时间后重试
+				if swiftxClient.compensate {
+					if skew, ok := signatureSkew(response.RawResponse); ok && (skew > significantClockSkew || skew < -significantClockSkew) {
+						return true
+					}
+				}
+				used, ok := response.Request.Context().Value(credentialContextKey{}).(Credential)
+				return ok && credentials.authFailed(used)
 			}
 			return false
 		})
+	transport := swiftxClient.transport
+	if transport == nil {
+		if t, err := NewTransport(cfg, swiftxClient.tlsConfig); err != nil {
+			l.l.Error("transport", "error", err)
+			transport = errTransport{err: err}
+		} else {
+			transport = t
+		}
+	}
+	if swiftxClient.dryRun {
+		transport = &dryRunTransport{logger: l.l, redaction: swiftxClient.redaction, next: transport}
+	}
+	httpClient.SetTransport(&signingTransport{
+		signer:     swiftxClient.signer,
+		clock:      swiftxClient.clock,
+		compensate: swiftxClient.compensate,
+		next:       transport,
+	})
 	if debug {
 		httpClient.EnableTrace()
 	}
+	// 调试模式输出的请求、响应内容包含收发件人信息和面单内容，需要脱敏
+	httpClient.SetLogger(debugLogger{l})
+	swiftxClient.redaction.redactDebugLog(httpClient)
 	swiftxClient.httpClient = httpClient
+	middlewares := swiftxClient.middlewares
+	if swiftxClient.breaker != nil {
+		middlewares = append(middlewares[:len(middlewares):len(middlewares)], swiftxClient.breaker.middleware())
+	}
+	if swiftxClient.requestLog != nil {
+		middlewares = append(middlewares[:len(middlewares):len(middlewares)], requestLogMiddleware(l.l, *swiftxClient.requestLog, swiftxClient.redaction))
+	}
+	if swiftxClient.harRecorder != nil {
+		swiftxClient.harRecorder.attach(httpClient, swiftxClient.redaction)
+		middlewares = append(middlewares[:len(middlewares):len(middlewares)], swiftxClient.harRecorder.middleware())
+	}
 	xService := service{
-		config:     &cfg,
-		logger:     l.l,
-		httpClient: swiftxClient.httpClient,
+		config:  &cfg,
+		logger:  l.l,
+		handler: chain(restyHandler{httpClient: httpClient}, middlewares),
+		limits:  swiftxClient.limits,
+		dryRun:  swiftxClient.dryRun,
 	}
 	swiftxClient.Services = services{
 		Order: (orderService)(xService),
@@ -236,7 +257,13 @@ func recheckError(resp *resty.Response, e error) error {
 	}
 
 	if resp.IsError() {
-		return errorWrap(resp.StatusCode(), "")
+		err := errorWrap(resp.StatusCode(), "")
+		if resp.StatusCode() == http.StatusUnauthorized {
+			if skew, ok := signatureSkew(resp.RawResponse); ok && (skew > significantClockSkew || skew < -significantClockSkew) {
+				return fmt.Errorf("%w，签名时间与服务器时间相差 %s，请检查本地时钟", err, skew)
+			}
+		}
+		return err
 	}
 
 	var result response.Result
@@ -247,5 +274,5 @@ func recheckError(resp *resty.Response, e error) error {
 	if result.Success {
 		return nil
 	}
-	return errors.New(result.Message)
+	return &ResultError{Message: result.Message}
 }
@@ -2,29 +2,50 @@ package swiftx
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
-	"os"
+	"net/http"
+	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/hiscaler/swiftx-go/config"
+	"github.com/hiscaler/swiftx-go/entity"
 )
 
 var client *Client
 var ctx context.Context
 
+// TestMain 加载测试配置，config.json 中只有占位密钥，
+// 运行访问接口的测试时需要通过 SWIFTX_APP_SECRET 或 SWIFTX_APP_SECRET_FILE 环境变量提供测试账号密钥
 func TestMain(m *testing.M) {
-	b, err := os.ReadFile("./config/config.json")
+	cfg, err := config.Load("./config/config.json")
 	if err != nil {
-		panic(fmt.Sprintf("Read config error: %s", err.Error()))
-	}
-	var cfg config.Config
-	err = json.Unmarshal(b, &cfg)
-	if err != nil {
-		panic(fmt.Sprintf("Parse config file error: %s", err.Error()))
+		panic(fmt.Sprintf("Load config error: %s", err.Error()))
 	}
 
 	client = NewClient(cfg)
 	ctx = context.Background()
 	m.Run()
 }
+
+// newTestClient 创建请求发送到 handler 的测试客户端
+func newTestClient(t *testing.T, handler http.HandlerFunc, opts ...Option) *Client {
+	srv := httptest.NewServer(handler)
+	t.Cleanup(srv.Close)
+	c := NewClient(config.Config{
+		Env:       entity.Test,
+		Timeout:   5,
+		AppKey:    "test-app-key",
+		AppSecret: "test-app-secret",
+	}, opts...)
+	c.httpClient.
+		SetBaseURL(srv.URL + "/api/v2/openapi").
+		SetRetryWaitTime(time.Millisecond).
+		SetRetryMaxWaitTime(time.Millisecond)
+	return c
+}
+
+// validSignature 检查请求签名是否使用 secret 生成
+func validSignature(r *http.Request, secret string) bool {
+	return NewVerifier(time.Minute).Verify(r, secret) == nil
+}
@@ -0,0 +1,69 @@
+package swiftx
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"sync/atomic"
+	"time"
+)
+
+// significantClockSkew 超过该误差时，身份验证失败的错误信息中会提示检查本地时钟
+const significantClockSkew = 30 * time.Second
+
+// serverClock 根据响应的 Date 头记录本地时钟与服务器时钟的误差
+type serverClock struct {
+	offset atomic.Int64 // 服务器时间 - 本地时间（纳秒）
+}
+
+// observe 根据响应的 Date 头更新误差，sent 和 received 为请求发送和响应接收的本地时间
+func (c *serverClock) observe(date string, sent, received time.Time) {
+	if date == "" {
+		return
+	}
+	serverTime, err := http.ParseTime(date)
+	if err != nil {
+		return
+	}
+	// Date 只精确到秒，取半秒作为服务器时间的估计值，与请求往返的中间时间比较
+	local := sent.Add(received.Sub(sent) / 2)
+	offset := serverTime.Add(500 * time.Millisecond).Sub(local).Round(time.Second)
+	if offset > -2*time.Second && offset < 2*time.Second {
+		// Date 精度不足以判断更小的误差
+		offset = 0
+	}
+	c.offset.Store(int64(offset))
+}
+
+func (c *serverClock) skew() time.Duration {
+	return time.Duration(c.offset.Load())
+}
+
+// ClockSkew 返回最近一次从响应 Date 头中得到的服务器时间与本地时间的误差（服务器时间 - 本地时间）
+func (c *Client) ClockSkew() time.Duration {
+	return c.clock.skew()
+}
+
+// SyncClock 通过 pingPong 接口获取服务器时间并更新时钟误差
+func (c *Client) SyncClock(ctx context.Context) (time.Duration, error) {
+	if _, err := c.Services.Ping.Pong(ctx, int(time.Now().Unix())); err != nil {
+		return c.ClockSkew(), err
+	}
+	return c.ClockSkew(), nil
+}
+
+// signatureSkew 返回签名时间戳与服务器响应时间的误差，无法判断时返回 false
+func signatureSkew(resp *http.Response) (time.Duration, bool) {
+	if resp == nil || resp.Request == nil {
+		return 0, false
+	}
+	timestamp, err := strconv.ParseInt(resp.Request.Header.Get(HeaderTimestamp), 10, 64)
+	if err != nil {
+		return 0, false
+	}
+	serverTime, err := http.ParseTime(resp.Header.Get("Date"))
+	if err != nil {
+		return 0, false
+	}
+	return time.Unix(timestamp, 0).Sub(serverTime), true
+}
@@ -0,0 +1,61 @@
+package swiftx
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestServerClock_Observe(t *testing.T) {
+	var c serverClock
+	now := time.Now()
+	c.observe(now.Add(10*time.Minute).UTC().Format(http.TimeFormat), now, now.Add(100*time.Millisecond))
+	assert.InDelta(t, float64(10*time.Minute), float64(c.skew()), float64(2*time.Second))
+
+	// 小于 Date 精度的误差忽略
+	c.observe(now.UTC().Format(http.TimeFormat), now, now)
+	assert.Equal(t, time.Duration(0), c.skew())
+
+	// 无效的 Date 不影响已记录的误差
+	c.offset.Store(int64(time.Minute))
+	c.observe("invalid", now, now)
+	assert.Equal(t, time.Minute, c.skew())
+}
+
+// skewedServer 模拟时钟比本地快 10 分钟的服务端，签名时间误差超过 1 分钟时返回 401
+func skewedServer(w http.ResponseWriter, r *http.Request) {
+	serverTime := time.Now().Add(10 * time.Minute)
+	w.Header().Set("Date", serverTime.UTC().Format(http.TimeFormat))
+	timestamp, _ := strconv.ParseInt(r.Header.Get(HeaderTimestamp), 10, 64)
+	if d := serverTime.Sub(time.Unix(timestamp, 0)); d > time.Minute || d < -time.Minute {
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	fmt.Fprint(w, r.URL.Query().Get("i"))
+}
+
+func TestClient_ClockSkewCompensation(t *testing.T) {
+	c := newTestClient(t, skewedServer)
+	n, err := c.Services.Ping.Pong(ctx, 7)
+	assert.NoError(t, err)
+	assert.Equal(t, 7, n)
+	assert.InDelta(t, float64(10*time.Minute), float64(c.ClockSkew()), float64(2*time.Second))
+
+	skew, err := c.SyncClock(ctx)
+	assert.NoError(t, err)
+	assert.InDelta(t, float64(10*time.Minute), float64(skew), float64(2*time.Second))
+}
+
+func TestClient_ClockSkewError(t *testing.T) {
+	c := newTestClient(t, skewedServer, WithClockSkewCompensation(false))
+	_, err := c.Services.Ping.Pong(ctx, 7)
+	if assert.Error(t, err) {
+		assert.True(t, strings.HasPrefix(err.Error(), "身份验证失败（无效签名），签名时间与服务器时间相差 -10m"), err.Error())
+	}
+}
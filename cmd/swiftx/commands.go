@@ -0,0 +1,185 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"math/rand"
+	"os"
+	"strconv"
+
+	"github.com/hiscaler/swiftx-go"
+	"github.com/hiscaler/swiftx-go/config"
+	"github.com/hiscaler/swiftx-go/entity"
+	"github.com/hiscaler/swiftx-go/printer"
+)
+
+func newFlagSet(name string) *flag.FlagSet {
+	fs := flag.NewFlagSet(name, flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+	return fs
+}
+
+// pingCommand swiftx ping [-i 数值]
+func pingCommand(ctx context.Context, client *swiftx.Client, args []string, out *output) error {
+	fs := newFlagSet("ping")
+	i := fs.Int("i", rand.Intn(1000000), "请求的数值")
+	if err := fs.Parse(args); err != nil {
+		return errUsage
+	}
+
+	res, err := client.Services.Ping.Pong(ctx, *i)
+	if err != nil {
+		return err
+	}
+	if res != *i {
+		return fmt.Errorf("响应数值不一致，期望 %d，实际 %d", *i, res)
+	}
+	return out.write(
+		map[string]int{"request": *i, "response": res},
+		[]string{"request", "response"},
+		[][]string{{strconv.Itoa(*i), strconv.Itoa(res)}},
+	)
+}
+
+// orderCommand swiftx order create|cancel
+func orderCommand(ctx context.Context, client *swiftx.Client, args []string, out *output) error {
+	if len(args) == 0 {
+		return errUsage
+	}
+
+	switch args[0] {
+	case "create":
+		fs := newFlagSet("order create")
+		filename := fs.String("f", "", "订单文件（JSON 或 YAML）")
+		labelFilename := fs.String("label", "", "面单保存路径")
+		if err := fs.Parse(args[1:]); err != nil || *filename == "" {
+			return errUsage
+		}
+
+		var req swiftx.CreateOrderRequest
+		if err := config.DecodeFile(*filename, &req); err != nil {
+			return fmt.Errorf("读取订单文件 %s 失败: %w", *filename, err)
+		}
+		order, err := client.Services.Order.Create(ctx, req)
+		if err != nil {
+			return err
+		}
+		if *labelFilename != "" {
+			if err = saveLabel(order, *labelFilename); err != nil {
+				return err
+			}
+		}
+		return out.write(
+			order,
+			[]string{"customer_order_number", "shipment_number", "tracking_number"},
+			[][]string{{order.CustomerOrderNumber, order.ShipmentNumber, order.TrackingNumber.ValueOrZero()}},
+		)
+	case "cancel":
+		if len(args) != 2 {
+			return errUsage
+		}
+		shipmentNumber := args[1]
+		ok, err := client.Services.Order.Cancel(ctx, shipmentNumber)
+		if err != nil {
+			return err
+		}
+		return out.write(
+			map[string]any{"shipment_number": shipmentNumber, "cancelled": ok},
+			[]string{"shipment_number", "cancelled"},
+			[][]string{{shipmentNumber, strconv.FormatBool(ok)}},
+		)
+	default:
+		return errUsage
+	}
+}
+
+// trackCommand swiftx track <SwiftX 订单号>...
+func trackCommand(ctx context.Context, client *swiftx.Client, args []string, out *output) error {
+	if len(args) == 0 {
+		return errUsage
+	}
+
+	results, err := client.Services.Order.Tracking(ctx, args...)
+	if err != nil {
+		return err
+	}
+	rows := make([][]string, 0)
+	for _, result := range results {
+		if len(result.TrackingEventList) == 0 {
+			rows = append(rows, []string{result.TrackingNo, "", "", "", ""})
+			continue
+		}
+		for _, track := range result.TrackingEventList {
+			rows = append(rows, []string{result.TrackingNo, track.LocalTime, track.Event, track.Description, track.Location})
+		}
+	}
+	return out.write(results, []string{"tracking_no", "local_time", "event", "description", "location"}, rows)
+}
+
+// priceCommand swiftx price <SwiftX 订单号>...
+func priceCommand(ctx context.Context, client *swiftx.Client, args []string, out *output) error {
+	if len(args) == 0 {
+		return errUsage
+	}
+
+	prices, err := client.Services.Order.Postage(ctx, args...)
+	if err != nil {
+		return err
+	}
+	rows := make([][]string, len(prices))
+	for i, price := range prices {
+		rows[i] = []string{
+			price.TrackingNumber,
+			price.Amount.Decimal(),
+			string(price.Amount.Currency),
+		}
+	}
+	return out.write(prices, []string{"tracking_number", "amount", "currency_code"}, rows)
+}
+
+// labelCommand swiftx label save [-in order.json] -out label.pdf
+func labelCommand(args []string, stdin io.Reader, out *output) error {
+	if len(args) == 0 || args[0] != "save" {
+		return errUsage
+	}
+
+	fs := newFlagSet("label save")
+	in := fs.String("in", "", "order create -format json 的输出文件，未指定时从标准输入读取")
+	filename := fs.String("out", "", "面单保存路径")
+	if err := fs.Parse(args[1:]); err != nil || *filename == "" {
+		return errUsage
+	}
+
+	r := stdin
+	if *in != "" {
+		f, err := os.Open(*in)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		r = f
+	}
+	var order entity.Order
+	if err := json.NewDecoder(r).Decode(&order); err != nil {
+		return fmt.Errorf("解析订单数据失败: %w", err)
+	}
+	if err := saveLabel(order, *filename); err != nil {
+		return err
+	}
+	return out.write(
+		map[string]string{"shipment_number": order.ShipmentNumber, "file": *filename},
+		[]string{"shipment_number", "file"},
+		[][]string{{order.ShipmentNumber, *filename}},
+	)
+}
+
+func saveLabel(order entity.Order, filename string) error {
+	b, err := printer.DecodeLabel(order)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filename, b, 0644)
+}
@@ -0,0 +1,27 @@
+package main
+
+import (
+	"github.com/hiscaler/swiftx-go"
+	"github.com/hiscaler/swiftx-go/config"
+	"github.com/hiscaler/swiftx-go/entity"
+)
+
+// defaultConfig 命令行工具的默认配置，只需要通过环境变量或配置文件提供 App Key 和 App Secret
+var defaultConfig = config.Config{
+	Env:     entity.Prod,
+	Timeout: 30,
+}
+
+// loadConfig 从配置文件和环境变量中加载配置，未设置的配置项使用 defaultConfig
+func loadConfig(filename string) (config.Config, error) {
+	return config.LoadWithDefaults(defaultConfig, filename)
+}
+
+// newClient 根据配置文件和环境变量创建 API 客户端
+func newClient(filename string, opts ...swiftx.Option) (*swiftx.Client, error) {
+	cfg, err := loadConfig(filename)
+	if err != nil {
+		return nil, err
+	}
+	return swiftx.NewClient(cfg, opts...), nil
+}
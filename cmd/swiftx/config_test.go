@@ -0,0 +1,37 @@
+package main
+
+import (
+	"os"
+	"testing"
+
+	"github.com/hiscaler/swiftx-go/config"
+	"github.com/hiscaler/swiftx-go/entity"
+	"github.com/stretchr/testify/assert"
+)
+
+// clearEnv 清除 SWIFTX_* 环境变量，测试结束后恢复
+func clearEnv(t *testing.T) {
+	for _, name := range []string{
+		config.EnvDebug, config.EnvEnv, config.EnvTimeout, config.EnvAppKey, config.EnvAppSecret,
+		config.EnvAppSecretFile, config.EnvCallbackUrl, config.EnvProxy, config.EnvCACertFile,
+	} {
+		t.Setenv(name, "")
+		os.Unsetenv(name)
+	}
+}
+
+func TestLoadConfig_CredentialsOnly(t *testing.T) {
+	clearEnv(t)
+	t.Setenv(config.EnvAppKey, "key")
+	t.Setenv(config.EnvAppSecret, "secret")
+
+	cfg, err := loadConfig("")
+	assert.NoError(t, err)
+	assert.Equal(t, entity.Prod, cfg.Env)
+	assert.Equal(t, 30, cfg.Timeout)
+	assert.Equal(t, "key", cfg.AppKey)
+	assert.Equal(t, "secret", cfg.AppSecret)
+
+	_, err = newClient("")
+	assert.NoError(t, err)
+}
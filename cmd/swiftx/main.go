@@ -0,0 +1,97 @@
+// swiftx 是 SwiftX Express API 的命令行工具，支持连通性测试、创建/取消订单、查询轨迹和价格以及保存面单。
+//
+// 配置从 -config 指定的文件和 SWIFTX_* 环境变量中读取，详见 config.Load。
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+
+	"github.com/hiscaler/swiftx-go"
+)
+
+const usage = `swiftx - SwiftX Express API 命令行工具
+
+用法:
+  swiftx [选项] <命令> [参数]
+
+选项:
+  -config string  配置文件路径（JSON 或 YAML），SWIFTX_* 环境变量会覆盖文件中的配置
+  -format string  输出格式：table、json、csv（默认 table）
+  -dry-run        试运行，创建/取消订单时只校验并签名请求，不发送到 SwiftX
+
+命令:
+  ping [-i 数值]                             测试 API 连通性
+  order create -f <文件> [-label <文件>]      创建订单，订单文件支持 JSON 和 YAML 格式
+  order cancel <SwiftX 订单号>                取消订单
+  track <SwiftX 订单号>...                    查询物流轨迹
+  price <SwiftX 订单号>...                    查询订单价格
+  label save [-in <文件>] -out <文件>         保存 order create -format json 输出中的面单
+
+环境变量:
+  SWIFTX_ENV, SWIFTX_TIMEOUT, SWIFTX_DEBUG, SWIFTX_APP_KEY, SWIFTX_APP_SECRET,
+  SWIFTX_APP_SECRET_FILE, SWIFTX_CALLBACK_URL（环境变量优先于配置文件）
+  未设置时环境默认为 prod，超时时间默认为 30 秒
+`
+
+var errUsage = errors.New("usage")
+
+func main() {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	err := run(ctx, os.Args[1:], os.Stdin, os.Stdout)
+	stop()
+	if err != nil {
+		if errors.Is(err, errUsage) {
+			fmt.Fprint(os.Stderr, usage)
+			os.Exit(2)
+		}
+		fmt.Fprintln(os.Stderr, "错误:", err)
+		os.Exit(1)
+	}
+}
+
+func run(ctx context.Context, args []string, stdin io.Reader, stdout io.Writer) error {
+	fs := flag.NewFlagSet("swiftx", flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+	configFile := fs.String("config", "", "配置文件路径")
+	format := fs.String("format", formatTable, "输出格式")
+	dryRun := fs.Bool("dry-run", false, "试运行")
+	if err := fs.Parse(args); err != nil {
+		return errUsage
+	}
+	args = fs.Args()
+	if len(args) == 0 {
+		return errUsage
+	}
+
+	out, err := newOutput(stdout, *format)
+	if err != nil {
+		return err
+	}
+	// 保存面单不需要调用 API
+	if args[0] == "label" {
+		return labelCommand(args[1:], stdin, out)
+	}
+
+	client, err := newClient(*configFile, swiftx.WithDryRun(*dryRun))
+	if err != nil {
+		return err
+	}
+	switch args[0] {
+	case "ping":
+		return pingCommand(ctx, client, args[1:], out)
+	case "order":
+		return orderCommand(ctx, client, args[1:], out)
+	case "track":
+		return trackCommand(ctx, client, args[1:], out)
+	case "price":
+		return priceCommand(ctx, client, args[1:], out)
+	default:
+		return errUsage
+	}
+}
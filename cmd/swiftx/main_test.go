@@ -0,0 +1,152 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/hiscaler/swiftx-go"
+	"github.com/hiscaler/swiftx-go/config"
+	"github.com/hiscaler/swiftx-go/entity"
+	"github.com/stretchr/testify/assert"
+)
+
+func writeFile(t *testing.T, name, content string) string {
+	filename := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(filename, []byte(content), 0600); err != nil {
+		t.Fatal(err)
+	}
+	return filename
+}
+
+func writeOrderFile(t *testing.T) string {
+	req := swiftx.CreateOrderRequest{
+		OrderScope:        entity.OrderScopeDomestic,
+		ServiceType:       entity.ServiceTypeExp,
+		DeliveryMethod:    entity.DeliveryMethodHdy,
+		CooperationMethod: entity.CooperationMethodMerchant,
+		PackageInfo: swiftx.CreateOrderPackageInformation{
+			SenderAddress:    swiftx.SenderAddress{Name: "ZEB2", RegionCode: "US", StateProvince: "CA", City: "Ontario", StreetAddress: "2078 E Francis Street", PostalCode: "91761"},
+			RecipientAddress: swiftx.RecipientAddress{Name: "Dak Jaech", PhoneNumber: "13474473197", RegionCode: "US", StateProvince: "TX", City: "Fort Worth", StreetAddress: "W1302 WELCH RD", PostalCode: "76118"},
+			Weight:           1.5,
+			Length:           10,
+			Width:            10,
+			Height:           5,
+			Value:            swiftx.Value{Money: entity.NewMoney(10000, entity.CurrencyUSD)},
+			SkuList:          []swiftx.CreateOrderPackageGoods{{Name: "SKU 1", Quantity: 1}},
+		},
+		ShippingLabelInfo: swiftx.ShippingLabelInformation{OrderNumber: "TEST-ORDER-1"},
+	}
+	b, err := json.Marshal(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return writeFile(t, "order.json", string(b))
+}
+
+func TestRun(t *testing.T) {
+	clearEnv(t)
+	t.Setenv(config.EnvAppKey, "key")
+	t.Setenv(config.EnvAppSecret, "secret")
+
+	configFile := writeFile(t, "config.yaml", "env: test\ntimeout: 5\n")
+	invalidConfigFile := writeFile(t, "invalid.yaml", "timeout: -1\n")
+	orderFile := writeOrderFile(t)
+	labelFile := filepath.Join(t.TempDir(), "label.pdf")
+	savedLabelFile := filepath.Join(t.TempDir(), "saved.pdf")
+	order, _ := json.Marshal(entity.Order{ShipmentNumber: "SWX1", ShippingLabel: base64.StdEncoding.EncodeToString([]byte("%PDF-1.4"))})
+
+	tests := []struct {
+		name    string
+		args    []string
+		stdin   string
+		wantErr string // 为 usage 时期望 errUsage
+		check   func(t *testing.T, out string)
+	}{
+		{name: "no command", args: nil, wantErr: "usage"},
+		{name: "unknown flag", args: []string{"-x", "ping"}, wantErr: "usage"},
+		{name: "unknown command", args: []string{"foo"}, wantErr: "usage"},
+		{name: "invalid format", args: []string{"-format", "xml", "ping"}, wantErr: "无效的输出格式 xml"},
+		{name: "order without subcommand", args: []string{"order"}, wantErr: "usage"},
+		{name: "order create without file", args: []string{"order", "create"}, wantErr: "usage"},
+		{name: "order cancel without number", args: []string{"order", "cancel"}, wantErr: "usage"},
+		{name: "track without number", args: []string{"track"}, wantErr: "usage"},
+		{name: "price without number", args: []string{"price"}, wantErr: "usage"},
+		{name: "label save without out", args: []string{"label", "save"}, wantErr: "usage"},
+		{name: "invalid config", args: []string{"-config", invalidConfigFile, "track", "SWX1"}, wantErr: "超时时间必须大于 0"},
+		{name: "missing config", args: []string{"-config", filepath.Join(t.TempDir(), "missing.yaml"), "track", "SWX1"}, wantErr: "missing.yaml"},
+		{name: "missing order file", args: []string{"-dry-run", "order", "create", "-f", filepath.Join(t.TempDir(), "missing.json")}, wantErr: "读取订单文件"},
+		{
+			name: "order create table",
+			args: []string{"-dry-run", "-config", configFile, "order", "create", "-f", orderFile, "-label", labelFile},
+			check: func(t *testing.T, out string) {
+				lines := strings.Split(strings.TrimSpace(out), "\n")
+				if assert.Len(t, lines, 2) {
+					assert.Equal(t, []string{"customer_order_number", "shipment_number", "tracking_number"}, strings.Fields(lines[0]))
+					fields := strings.Fields(lines[1])
+					assert.Equal(t, "TEST-ORDER-1", fields[0])
+					assert.True(t, swiftx.IsDryRunShipmentNumber(fields[1]))
+				}
+				b, err := os.ReadFile(labelFile)
+				if assert.NoError(t, err) {
+					assert.True(t, bytes.HasPrefix(b, []byte("%PDF")))
+				}
+			},
+		},
+		{
+			name: "order create json",
+			args: []string{"-dry-run", "-format", "json", "order", "create", "-f", orderFile},
+			check: func(t *testing.T, out string) {
+				var order entity.Order
+				if assert.NoError(t, json.Unmarshal([]byte(out), &order)) {
+					assert.Equal(t, "TEST-ORDER-1", order.CustomerOrderNumber)
+					assert.True(t, swiftx.IsDryRunShipmentNumber(order.ShipmentNumber))
+					assert.NotEmpty(t, order.ShippingLabel)
+				}
+			},
+		},
+		{
+			name: "order cancel csv",
+			args: []string{"-dry-run", "-format", "CSV", "order", "cancel", "SWX1"},
+			check: func(t *testing.T, out string) {
+				assert.Equal(t, "shipment_number,cancelled\nSWX1,true\n", out)
+			},
+		},
+		{
+			name:  "label save",
+			args:  []string{"label", "save", "-out", savedLabelFile},
+			stdin: string(order),
+			check: func(t *testing.T, out string) {
+				assert.Equal(t, []string{"shipment_number", "file", "SWX1", savedLabelFile}, strings.Fields(out))
+				b, err := os.ReadFile(savedLabelFile)
+				if assert.NoError(t, err) {
+					assert.Equal(t, "%PDF-1.4", string(b))
+				}
+			},
+		},
+		{name: "label save invalid input", args: []string{"label", "save", "-out", savedLabelFile}, stdin: "{", wantErr: "解析订单数据失败"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var stdout bytes.Buffer
+			err := run(context.Background(), tt.args, strings.NewReader(tt.stdin), &stdout)
+			switch tt.wantErr {
+			case "":
+				if assert.NoError(t, err) && tt.check != nil {
+					tt.check(t, stdout.String())
+				}
+			case "usage":
+				assert.ErrorIs(t, err, errUsage)
+			default:
+				if assert.Error(t, err) {
+					assert.Contains(t, err.Error(), tt.wantErr)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,35 @@
+package main
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOutput_Write(t *testing.T) {
+	v := []map[string]string{{"tracking_no": "SWX1", "event": "A & B"}}
+	header := []string{"tracking_no", "event"}
+	rows := [][]string{{"SWX1", "A & B"}, {"SWX10", "a,b"}}
+
+	tests := []struct {
+		format string
+		want   string
+	}{
+		{formatTable, "tracking_no  event\nSWX1         A & B\nSWX10        a,b\n"},
+		{" Table ", "tracking_no  event\nSWX1         A & B\nSWX10        a,b\n"},
+		{formatCSV, "tracking_no,event\nSWX1,A & B\nSWX10,\"a,b\"\n"},
+		{formatJSON, "[\n  {\n    \"event\": \"A & B\",\n    \"tracking_no\": \"SWX1\"\n  }\n]\n"},
+	}
+	for _, tt := range tests {
+		var buf bytes.Buffer
+		out, err := newOutput(&buf, tt.format)
+		if assert.NoError(t, err, tt.format) {
+			assert.NoError(t, out.write(v, header, rows))
+			assert.Equal(t, tt.want, buf.String(), tt.format)
+		}
+	}
+
+	_, err := newOutput(&bytes.Buffer{}, "xml")
+	assert.EqualError(t, err, "无效的输出格式 xml")
+}
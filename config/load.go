@@ -0,0 +1,142 @@
+package config
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/hiscaler/swiftx-go/entity"
+	"gopkg.in/yaml.v3"
+)
+
+// 环境变量
+const (
+	EnvDebug         = "SWIFTX_DEBUG"
+	EnvEnv           = "SWIFTX_ENV"
+	EnvTimeout       = "SWIFTX_TIMEOUT"
+	EnvAppKey        = "SWIFTX_APP_KEY"
+	EnvAppSecret     = "SWIFTX_APP_SECRET"
+	EnvAppSecretFile = "SWIFTX_APP_SECRET_FILE"
+	EnvCallbackUrl   = "SWIFTX_CALLBACK_URL"
+	EnvProxy         = "SWIFTX_PROXY"
+	EnvCACertFile    = "SWIFTX_CA_CERT_FILE"
+)
+
+// Load 加载配置
+//
+// 先读取配置文件（支持 JSON 和 YAML 格式，filename 为空时跳过），然后使用 SWIFTX_* 环境变量覆盖对应的配置项，
+// 设置了 AppSecretFile 时从该文件中读取密钥，最后验证配置是否有效。
+func Load(filename string) (Config, error) {
+	return LoadWithDefaults(Config{}, filename)
+}
+
+// LoadWithDefaults 与 Load 相同，配置文件和环境变量中没有设置的配置项使用 defaults 中的值
+func LoadWithDefaults(defaults Config, filename string) (Config, error) {
+	cfg := defaults
+	if filename != "" {
+		if err := DecodeFile(filename, &cfg); err != nil {
+			return cfg, fmt.Errorf("config: 读取配置文件 %s 失败: %w", filename, err)
+		}
+	}
+	if err := applyEnv(&cfg); err != nil {
+		return cfg, err
+	}
+	if cfg.AppSecretFile != "" {
+		b, err := os.ReadFile(cfg.AppSecretFile)
+		if err != nil {
+			return cfg, fmt.Errorf("config: 读取密钥文件 %s 失败: %w", cfg.AppSecretFile, err)
+		}
+		cfg.AppSecret = strings.TrimSpace(string(b))
+	}
+	if err := cfg.Validate(); err != nil {
+		return cfg, fmt.Errorf("config: %w", err)
+	}
+	return cfg, nil
+}
+
+// Validate 配置验证
+func (c Config) Validate() error {
+	return validation.ValidateStruct(&c,
+		validation.Field(&c.Env,
+			validation.Required.Error("环境不能为空"),
+			validation.In(entity.Prod, entity.Test, entity.Dev).Error("无效的环境"),
+		),
+		validation.Field(&c.Timeout, validation.Required.Error("超时时间不能为空"), validation.Min(1).Error("超时时间必须大于 0")),
+		validation.Field(&c.AppKey, validation.Required.Error("App Key 不能为空")),
+		validation.Field(&c.AppSecret, validation.Required.Error("App Secret 不能为空")),
+		validation.Field(&c.Proxy, validation.When(c.Proxy != "", validation.By(func(value interface{}) error {
+			u, err := url.Parse(value.(string))
+			if err != nil || u.Host == "" {
+				return errors.New("无效的代理地址")
+			}
+			switch u.Scheme {
+			case "http", "https", "socks5":
+				return nil
+			}
+			return errors.New("代理地址只支持 http、https 和 socks5 协议")
+		}))),
+		validation.Field(&c.MaxIdleConns, validation.Min(0).Error("最大空闲连接数不能小于 0")),
+		validation.Field(&c.MaxIdleConnsPerHost, validation.Min(0).Error("每个主机的最大空闲连接数不能小于 0")),
+		validation.Field(&c.MaxConnsPerHost, validation.Min(0).Error("每个主机的最大连接数不能小于 0")),
+		validation.Field(&c.IdleConnTimeout, validation.Min(0).Error("空闲连接超时时间不能小于 0")),
+	)
+}
+
+// DecodeFile 读取 JSON 或 YAML 文件到 v
+//
+// YAML 文件会先转换为 JSON 再解码，这样两种格式使用相同的字段名（json tag）和 UnmarshalJSON 方法。
+func DecodeFile(filename string, v any) error {
+	b, err := os.ReadFile(filename)
+	if err != nil {
+		return err
+	}
+	switch strings.ToLower(filepath.Ext(filename)) {
+	case ".yaml", ".yml":
+		var m any
+		if err = yaml.Unmarshal(b, &m); err != nil {
+			return err
+		}
+		if b, err = json.Marshal(m); err != nil {
+			return err
+		}
+	}
+	return json.Unmarshal(b, v)
+}
+
+func applyEnv(cfg *Config) error {
+	fields := map[string]*string{
+		EnvEnv:           &cfg.Env,
+		EnvAppKey:        &cfg.AppKey,
+		EnvAppSecret:     &cfg.AppSecret,
+		EnvAppSecretFile: &cfg.AppSecretFile,
+		EnvCallbackUrl:   &cfg.CallbackUrl,
+		EnvProxy:         &cfg.Proxy,
+		EnvCACertFile:    &cfg.CACertFile,
+	}
+	for name, p := range fields {
+		if v, ok := os.LookupEnv(name); ok {
+			*p = v
+		}
+	}
+	if v, ok := os.LookupEnv(EnvTimeout); ok {
+		timeout, err := strconv.Atoi(v)
+		if err != nil {
+			return fmt.Errorf("config: 无效的 %s 值 %s", EnvTimeout, v)
+		}
+		cfg.Timeout = timeout
+	}
+	if v, ok := os.LookupEnv(EnvDebug); ok {
+		debug, err := strconv.ParseBool(v)
+		if err != nil {
+			return fmt.Errorf("config: 无效的 %s 值 %s", EnvDebug, v)
+		}
+		cfg.Debug = debug
+	}
+	return nil
+}
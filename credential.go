@@ -0,0 +1,191 @@
+package swiftx
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hiscaler/swiftx-go/config"
+)
+
+// Credential 应用凭证
+type Credential struct {
+	AppKey    string // 应用程序的唯一标识符
+	AppSecret string // 密钥
+}
+
+func (c Credential) validate() error {
+	if c.AppKey == "" || c.AppSecret == "" {
+		return errors.New("App Key 或 App Secret 不能为空")
+	}
+	return nil
+}
+
+// CredentialProvider 凭证提供者，每次请求签名时都会调用，用于在不重建 Client 的情况下轮换密钥
+type CredentialProvider interface {
+	Credential(ctx context.Context) (Credential, error)
+}
+
+// StaticCredentials 固定凭证
+type StaticCredentials Credential
+
+func (s StaticCredentials) Credential(_ context.Context) (Credential, error) {
+	c := Credential(s)
+	return c, c.validate()
+}
+
+// EnvCredentials 从环境变量中读取凭证，未指定环境变量名称时使用 SWIFTX_APP_KEY 和 SWIFTX_APP_SECRET
+type EnvCredentials struct {
+	AppKeyEnv    string // App Key 环境变量名称
+	AppSecretEnv string // App Secret 环境变量名称
+}
+
+func (e EnvCredentials) Credential(_ context.Context) (Credential, error) {
+	appKeyEnv := e.AppKeyEnv
+	if appKeyEnv == "" {
+		appKeyEnv = config.EnvAppKey
+	}
+	appSecretEnv := e.AppSecretEnv
+	if appSecretEnv == "" {
+		appSecretEnv = config.EnvAppSecret
+	}
+	c := Credential{
+		AppKey:    os.Getenv(appKeyEnv),
+		AppSecret: os.Getenv(appSecretEnv),
+	}
+	return c, c.validate()
+}
+
+// FileCredentials 从文件中读取密钥，文件修改后自动加载新的密钥
+type FileCredentials struct {
+	appKey    string
+	filename  string
+	interval  time.Duration
+	mu        sync.Mutex
+	secret    string
+	modTime   time.Time
+	size      int64
+	checkedAt time.Time
+}
+
+// NewFileCredentials 创建文件凭证，interval 为检查文件是否修改的最小间隔（默认 10 秒）
+func NewFileCredentials(appKey, filename string, interval time.Duration) *FileCredentials {
+	if interval <= 0 {
+		interval = 10 * time.Second
+	}
+	return &FileCredentials{
+		appKey:   appKey,
+		filename: filename,
+		interval: interval,
+	}
+}
+
+func (f *FileCredentials) Credential(_ context.Context) (Credential, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	now := time.Now()
+	if f.secret == "" || now.Sub(f.checkedAt) >= f.interval {
+		if err := f.reload(); err != nil {
+			// 文件替换过程中可能暂时无法读取，继续使用已加载的密钥
+			if f.secret == "" {
+				return Credential{}, err
+			}
+		} else {
+			f.checkedAt = now
+		}
+	}
+	c := Credential{AppKey: f.appKey, AppSecret: f.secret}
+	return c, c.validate()
+}
+
+func (f *FileCredentials) reload() error {
+	fi, err := os.Stat(f.filename)
+	if err != nil {
+		return fmt.Errorf("读取密钥文件 %s 失败: %w", f.filename, err)
+	}
+	if f.secret != "" && fi.ModTime().Equal(f.modTime) && fi.Size() == f.size {
+		return nil
+	}
+
+	b, err := os.ReadFile(f.filename)
+	if err != nil {
+		return fmt.Errorf("读取密钥文件 %s 失败: %w", f.filename, err)
+	}
+	secret := strings.TrimSpace(string(b))
+	if secret == "" {
+		return fmt.Errorf("密钥文件 %s 内容为空", f.filename)
+	}
+	f.secret = secret
+	f.modTime = fi.ModTime()
+	f.size = fi.Size()
+	return nil
+}
+
+// credentialRotation 凭证轮换
+//
+// 凭证提供者返回新凭证后，在宽限期内继续使用旧凭证签名，旧凭证身份验证失败后再改用新凭证重试，
+// 宽限期结束后直接使用新凭证。宽限期为 0 时立即使用新凭证。
+type credentialRotation struct {
+	provider    CredentialProvider
+	gracePeriod time.Duration
+	mu          sync.Mutex
+	active      Credential  // 当前使用的凭证
+	pending     *Credential // 宽限期内等待启用的新凭证
+	pendingAt   time.Time   // 发现新凭证的时间
+	onRotate    func(from, to Credential)
+}
+
+func (r *credentialRotation) credential(ctx context.Context) (Credential, error) {
+	c, err := r.provider.Credential(ctx)
+	if err != nil {
+		return Credential{}, err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	switch {
+	case r.active == (Credential{}):
+		r.active = c
+	case c == r.active:
+		r.pending = nil
+	case r.gracePeriod <= 0:
+		r.rotate(c)
+	case r.pending == nil || *r.pending != c:
+		r.pending = &c
+		r.pendingAt = time.Now()
+	case time.Since(r.pendingAt) >= r.gracePeriod:
+		r.rotate(c)
+	}
+	return r.active, nil
+}
+
+// authFailed 使用 used 凭证签名的请求身份验证失败，返回是否需要使用新凭证重试
+func (r *credentialRotation) authFailed(used Credential) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if used != r.active {
+		// 其他请求已经切换了凭证
+		return true
+	}
+	if r.pending == nil {
+		return false
+	}
+	r.rotate(*r.pending)
+	return true
+}
+
+func (r *credentialRotation) rotate(c Credential) {
+	from := r.active
+	r.active = c
+	r.pending = nil
+	if r.onRotate != nil {
+		r.onRotate(from, c)
+	}
+}
+
+type credentialContextKey struct{}
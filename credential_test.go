@@ -0,0 +1,131 @@
+package swiftx
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// mutableCredentials 测试用的可修改凭证
+type mutableCredentials struct {
+	mu sync.Mutex
+	c  Credential
+}
+
+func (m *mutableCredentials) set(c Credential) {
+	m.mu.Lock()
+	m.c = c
+	m.mu.Unlock()
+}
+
+func (m *mutableCredentials) Credential(_ context.Context) (Credential, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.c, m.c.validate()
+}
+
+func TestEnvCredentials(t *testing.T) {
+	t.Setenv("TEST_SWIFTX_APP_KEY", "key")
+	t.Setenv("TEST_SWIFTX_APP_SECRET", "secret")
+	c, err := EnvCredentials{AppKeyEnv: "TEST_SWIFTX_APP_KEY", AppSecretEnv: "TEST_SWIFTX_APP_SECRET"}.Credential(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, Credential{AppKey: "key", AppSecret: "secret"}, c)
+
+	t.Setenv("TEST_SWIFTX_APP_SECRET", "")
+	_, err = EnvCredentials{AppKeyEnv: "TEST_SWIFTX_APP_KEY", AppSecretEnv: "TEST_SWIFTX_APP_SECRET"}.Credential(ctx)
+	assert.Error(t, err)
+}
+
+func TestFileCredentials(t *testing.T) {
+	filename := filepath.Join(t.TempDir(), "secret")
+	_, err := NewFileCredentials("key", filename, time.Nanosecond).Credential(ctx)
+	assert.Error(t, err)
+
+	assert.NoError(t, os.WriteFile(filename, []byte("old-secret\n"), 0600))
+	provider := NewFileCredentials("key", filename, time.Nanosecond)
+	c, err := provider.Credential(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, "old-secret", c.AppSecret)
+
+	assert.NoError(t, os.WriteFile(filename, []byte("rotated-secret\n"), 0600))
+	c, err = provider.Credential(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, "rotated-secret", c.AppSecret)
+
+	// 文件暂时不可读时继续使用已加载的密钥
+	assert.NoError(t, os.Remove(filename))
+	c, err = provider.Credential(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, "rotated-secret", c.AppSecret)
+}
+
+func TestClient_CredentialProvider(t *testing.T) {
+	provider := &mutableCredentials{c: Credential{AppKey: "key", AppSecret: "secret-1"}}
+	var serverSecret atomic.Value
+	serverSecret.Store("secret-1")
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if !validSignature(r, serverSecret.Load().(string)) {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, r.URL.Query().Get("i"))
+	}, WithCredentialProvider(provider))
+
+	n, err := c.Services.Ping.Pong(ctx, 1)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, n)
+
+	// 无宽限期时立即使用新密钥
+	provider.set(Credential{AppKey: "key", AppSecret: "secret-2"})
+	serverSecret.Store("secret-2")
+	n, err = c.Services.Ping.Pong(ctx, 2)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, n)
+}
+
+func TestClient_CredentialGracePeriod(t *testing.T) {
+	provider := &mutableCredentials{c: Credential{AppKey: "key", AppSecret: "old-secret"}}
+	var serverSecret atomic.Value
+	serverSecret.Store("old-secret")
+	var requests atomic.Int32
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		if !validSignature(r, serverSecret.Load().(string)) {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, r.URL.Query().Get("i"))
+	}, WithCredentialProvider(provider), WithCredentialGracePeriod(time.Hour))
+
+	_, err := c.Services.Ping.Pong(ctx, 1)
+	assert.NoError(t, err)
+
+	// 新密钥尚未在服务端生效，宽限期内继续使用旧密钥
+	provider.set(Credential{AppKey: "key", AppSecret: "new-secret"})
+	requests.Store(0)
+	_, err = c.Services.Ping.Pong(ctx, 2)
+	assert.NoError(t, err)
+	assert.Equal(t, int32(1), requests.Load())
+
+	// 服务端启用新密钥后，旧密钥验证失败，使用新密钥重试
+	serverSecret.Store("new-secret")
+	requests.Store(0)
+	_, err = c.Services.Ping.Pong(ctx, 3)
+	assert.NoError(t, err)
+	assert.Equal(t, int32(2), requests.Load())
+
+	// 两个密钥都无效时返回身份验证失败
+	serverSecret.Store("unknown-secret")
+	_, err = c.Services.Ping.Pong(ctx, 4)
+	assert.EqualError(t, err, "身份验证失败（无效签名）")
+}
@@ -0,0 +1,129 @@
+package swiftx
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"path"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// DryRunShipmentNumberPrefix 试运行模式返回的 SwiftX 订单号前缀
+const DryRunShipmentNumberPrefix = "DRYRUN-"
+
+// WithDryRun 设置试运行模式
+//
+// 试运行模式下创建订单和取消订单会完成参数校验、构建并签名请求，但不会发送到 SwiftX，而是记录将要发送的请求（已脱敏），
+// 创建订单返回以 DryRunShipmentNumberPrefix 开头的订单号和占位面单 PDF。查询类接口不受影响。
+func WithDryRun(enabled bool) Option {
+	return func(c *Client) {
+		c.dryRun = enabled
+	}
+}
+
+// IsDryRunShipmentNumber 是否为试运行模式生成的订单号
+func IsDryRunShipmentNumber(shipmentNumber string) bool {
+	return strings.HasPrefix(shipmentNumber, DryRunShipmentNumberPrefix)
+}
+
+// dryRunTransport 试运行模式下拦截签名后的写操作请求
+type dryRunTransport struct {
+	logger    *slog.Logger
+	redaction Redaction
+	next      http.RoundTripper
+}
+
+// isDryRunEndpoint 是否为试运行模式下拦截的写操作接口
+func isDryRunEndpoint(endpoint string) bool {
+	return endpoint == EndpointCreateOrder || endpoint == EndpointCancelOrder
+}
+
+func (t *dryRunTransport) RoundTrip(r *http.Request) (*http.Response, error) {
+	endpoint := path.Base(r.URL.Path)
+	if !isDryRunEndpoint(endpoint) {
+		return t.next.RoundTrip(r)
+	}
+
+	body, err := readBody(r)
+	if err != nil {
+		return nil, err
+	}
+	t.logger.LogAttrs(r.Context(), slog.LevelInfo, "swiftx dry run",
+		slog.String("endpoint", endpoint),
+		slog.String("method", r.Method),
+		slog.String("url", r.URL.String()),
+		slog.Any("header", t.redaction.Header(r.Header)),
+		slog.String("request", string(t.redaction.JSON(body))),
+	)
+
+	var result any
+	switch endpoint {
+	case EndpointCreateOrder:
+		res := CreateOrderResult{
+			TrackingNo: dryRunShipmentNumber(),
+			PdfBase64:  base64.StdEncoding.EncodeToString(dryRunLabel),
+		}
+		res.Success = true
+		result = res
+	default:
+		result = map[string]any{"success": true, "message": "dry run"}
+	}
+	b, err := json.Marshal(result)
+	if err != nil {
+		return nil, err
+	}
+	return &http.Response{
+		Status:     "200 OK",
+		StatusCode: http.StatusOK,
+		Proto:      "HTTP/1.1",
+		ProtoMajor: 1,
+		ProtoMinor: 1,
+		Header: http.Header{
+			"Content-Type":   []string{"application/json"},
+			"Content-Length": []string{strconv.Itoa(len(b))},
+		},
+		Body:          io.NopCloser(bytes.NewReader(b)),
+		ContentLength: int64(len(b)),
+		Request:       r,
+	}, nil
+}
+
+func dryRunShipmentNumber() string {
+	b := make([]byte, 4)
+	_, _ = rand.Read(b)
+	return DryRunShipmentNumberPrefix + time.Now().Format("20060102150405") + strings.ToUpper(hex.EncodeToString(b))
+}
+
+// dryRunLabel 试运行模式的占位面单（4x6 英寸，内容为 DRY RUN - NOT A VALID LABEL）
+var dryRunLabel = func() []byte {
+	content := "BT /F1 28 Tf 60 260 Td (DRY RUN) Tj /F1 12 Tf 0 -30 Td (NOT A VALID LABEL) Tj ET"
+	objects := []string{
+		"<< /Type /Catalog /Pages 2 0 R >>",
+		"<< /Type /Pages /Kids [3 0 R] /Count 1 >>",
+		"<< /Type /Page /Parent 2 0 R /MediaBox [0 0 288 432] /Contents 4 0 R /Resources << /Font << /F1 5 0 R >> >> >>",
+		fmt.Sprintf("<< /Length %d >>\nstream\n%s\nendstream", len(content), content),
+		"<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica >>",
+	}
+	var buf bytes.Buffer
+	buf.WriteString("%PDF-1.4\n")
+	offsets := make([]int, len(objects))
+	for i, object := range objects {
+		offsets[i] = buf.Len()
+		fmt.Fprintf(&buf, "%d 0 obj\n%s\nendobj\n", i+1, object)
+	}
+	xref := buf.Len()
+	fmt.Fprintf(&buf, "xref\n0 %d\n0000000000 65535 f \n", len(objects)+1)
+	for _, offset := range offsets {
+		fmt.Fprintf(&buf, "%010d 00000 n \n", offset)
+	}
+	fmt.Fprintf(&buf, "trailer\n<< /Size %d /Root 1 0 R >>\nstartxref\n%d\n%%%%EOF\n", len(objects)+1, xref)
+	return buf.Bytes()
+}()
@@ -0,0 +1,97 @@
+package swiftx
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/hiscaler/swiftx-go/config"
+	"github.com/hiscaler/swiftx-go/entity"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClient_DryRun(t *testing.T) {
+	var requests atomic.Int32
+	var buf bytes.Buffer
+	dryRunCalls := make(map[string]bool)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, r.URL.Query().Get("i"))
+	}))
+	defer srv.Close()
+	c := NewClient(config.Config{
+		Env:       entity.Test,
+		Timeout:   5,
+		AppKey:    "test-app-key",
+		AppSecret: "test-app-secret",
+		Logger:    slog.New(slog.NewTextHandler(&buf, nil)),
+	}, WithDryRun(true), WithMiddleware(func(next Handler) Handler {
+		return HandlerFunc(func(ctx context.Context, call *Call) error {
+			dryRunCalls[call.Endpoint] = call.DryRun
+			return next.Handle(ctx, call)
+		})
+	}))
+	c.httpClient.SetBaseURL(srv.URL)
+
+	request := CreateOrderRequest{
+		OrderScope:        entity.OrderScopeDomestic,
+		ServiceType:       entity.ServiceTypeExp,
+		DeliveryMethod:    entity.DeliveryMethodHdy,
+		CooperationMethod: entity.CooperationMethodMerchant,
+		PackageInfo: CreateOrderPackageInformation{
+			SenderAddress:    SenderAddress{Name: "ZEB2", RegionCode: "US", StateProvince: "CA", City: "Ontario", StreetAddress: "2078 E Francis Street", PostalCode: "91761"},
+			RecipientAddress: RecipientAddress{Name: "Dak Jaech", PhoneNumber: "13474473197", RegionCode: "US", StateProvince: "TX", City: "Fort Worth", StreetAddress: "W1302 WELCH RD", PostalCode: "76118"},
+			Weight:           1.5,
+			Length:           10,
+			Width:            10,
+			Height:           5,
+			Value:            Value{Money: entity.NewMoney(10000, entity.CurrencyUSD)},
+			SkuList:          []CreateOrderPackageGoods{{Name: "SKU 1", Quantity: 1}},
+		},
+		ShippingLabelInfo: ShippingLabelInformation{OrderNumber: "TEST-ORDER-1"},
+	}
+	order, err := c.Services.Order.Create(ctx, request)
+	assert.NoError(t, err)
+	assert.True(t, IsDryRunShipmentNumber(order.ShipmentNumber))
+	assert.Equal(t, "TEST-ORDER-1", order.CustomerOrderNumber)
+	label, err := base64.StdEncoding.DecodeString(order.ShippingLabel)
+	assert.NoError(t, err)
+	assert.True(t, bytes.HasPrefix(label, []byte("%PDF-")))
+	assert.Contains(t, string(label), "DRY RUN")
+
+	ok, err := c.Services.Order.Cancel(ctx, order.ShipmentNumber)
+	assert.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, int32(0), requests.Load())
+
+	// 仍然会校验参数
+	request.PackageInfo.RecipientAddress.City = ""
+	_, err = c.Services.Order.Create(ctx, request)
+	assert.Error(t, err)
+
+	// 查询类接口正常发送
+	n, err := c.Services.Ping.Pong(ctx, 9)
+	assert.NoError(t, err)
+	assert.Equal(t, 9, n)
+	assert.Equal(t, int32(1), requests.Load())
+	assert.Equal(t, map[string]bool{EndpointCreateOrder: true, EndpointCancelOrder: true, EndpointPingPong: false}, dryRunCalls)
+
+	output := buf.String()
+	assert.Equal(t, 2, strings.Count(output, `msg="swiftx dry run"`))
+	assert.Contains(t, output, "endpoint="+EndpointCreateOrder)
+	assert.Contains(t, output, HeaderSignature+":["+Redacted+"]")
+	assert.NotContains(t, output, "W1302 WELCH RD")
+
+	// 未启用试运行模式时不拦截
+	c = NewClient(config.Config{Env: entity.Test, Timeout: 5, AppKey: "k", AppSecret: "s"})
+	_, isDryRun := c.httpClient.GetClient().Transport.(*signingTransport).next.(*dryRunTransport)
+	assert.False(t, isDryRun)
+}
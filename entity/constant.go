@@ -6,23 +6,35 @@ const (
 	Dev  = "dev"  // 开发环境
 )
 
+// OrderScope 订单类型
+type OrderScope string
+
 const (
-	OrderScopeDomestic      = "DOMESTIC"      // 订单类型：国内
-	OrderScopeInternational = "INTERNATIONAL" // 订单类型：国际
+	OrderScopeDomestic      OrderScope = "DOMESTIC"      // 订单类型：国内
+	OrderScopeInternational OrderScope = "INTERNATIONAL" // 订单类型：国际
 )
 
+// ServiceType 服务类型
+type ServiceType string
+
 const (
-	ServiceTypeEco = "ECO" // 服务类型：特惠
-	ServiceTypeExp = "EXP" // 服务类型：标快
+	ServiceTypeEco ServiceType = "ECO" // 服务类型：特惠
+	ServiceTypeExp ServiceType = "EXP" // 服务类型：标快
 )
 
+// DeliveryMethod 送货方式
+type DeliveryMethod string
+
 const (
-	DeliveryMethodHdy = "HDY" // 送货方式：上门派送
-	DeliveryMethodSpu = "SPU" // 送货方式：自提
+	DeliveryMethodHdy DeliveryMethod = "HDY" // 送货方式：上门派送
+	DeliveryMethodSpu DeliveryMethod = "SPU" // 送货方式：自提
 )
 
+// CooperationMethod 合作方式
+type CooperationMethod string
+
 const (
-	CooperationMethodPlatform    = "PLATFORM"     // 合作方式：平台
-	CooperationMethodMerchant    = "MERCHANT"     // 合作方式：商家
-	CooperationMethodWesternPost = "WESTERN_POST" // 合作方式：西邮
+	CooperationMethodPlatform    CooperationMethod = "PLATFORM"     // 合作方式：平台
+	CooperationMethodMerchant    CooperationMethod = "MERCHANT"     // 合作方式：商家
+	CooperationMethodWesternPost CooperationMethod = "WESTERN_POST" // 合作方式：西邮
 )
@@ -0,0 +1,268 @@
+package entity
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// Language 标签语言
+type Language string
+
+const (
+	LanguageZh Language = "zh" // 中文
+	LanguageEn Language = "en" // 英文
+)
+
+// enumItem 枚举值及其中英文标签
+type enumItem[T ~string] struct {
+	value T
+	zh    string
+	en    string
+}
+
+// enum 枚举定义，是枚举可选值、标签和校验的唯一来源
+type enum[T ~string] struct {
+	name  string // 枚举名称，用于错误信息
+	items []enumItem[T]
+}
+
+func (e enum[T]) values() []T {
+	values := make([]T, len(e.items))
+	for i, item := range e.items {
+		values[i] = item.value
+	}
+	return values
+}
+
+func (e enum[T]) find(v T) (enumItem[T], bool) {
+	for _, item := range e.items {
+		if item.value == v {
+			return item, true
+		}
+	}
+	return enumItem[T]{}, false
+}
+
+func (e enum[T]) label(v T, lang Language) string {
+	item, ok := e.find(v)
+	if !ok {
+		return string(v)
+	}
+	if lang == LanguageEn {
+		return item.en
+	}
+	return item.zh
+}
+
+func (e enum[T]) validate(v T) error {
+	if _, ok := e.find(v); ok {
+		return nil
+	}
+	values := make([]string, len(e.items))
+	for i, item := range e.items {
+		values[i] = string(item.value)
+	}
+	return fmt.Errorf("无效的%s %s，可选值：%s", e.name, v, strings.Join(values, "、"))
+}
+
+// parse 解析枚举值，不区分大小写，也可以使用中英文标签
+func (e enum[T]) parse(s string) (T, error) {
+	s = strings.TrimSpace(s)
+	for _, item := range e.items {
+		if strings.EqualFold(s, string(item.value)) || s == item.zh || strings.EqualFold(s, item.en) {
+			return item.value, nil
+		}
+	}
+	return T(s), e.validate(T(s))
+}
+
+func (e enum[T]) unmarshalJSON(b []byte, v *T) error {
+	var s string
+	if err := json.Unmarshal(b, &s); err != nil {
+		return fmt.Errorf("无效的%s %s", e.name, b)
+	}
+	if s == "" {
+		*v = ""
+		return nil
+	}
+	value, err := e.parse(s)
+	if err != nil {
+		return err
+	}
+	*v = value
+	return nil
+}
+
+var orderScopes = enum[OrderScope]{name: "订单类型", items: []enumItem[OrderScope]{
+	{OrderScopeDomestic, "国内", "Domestic"},
+	{OrderScopeInternational, "国际", "International"},
+}}
+
+// OrderScopes 返回所有订单类型
+func OrderScopes() []OrderScope {
+	return orderScopes.values()
+}
+
+// ParseOrderScope 解析订单类型，不区分大小写，也可以使用中英文标签（比如“国内”）
+func ParseOrderScope(s string) (OrderScope, error) {
+	return orderScopes.parse(s)
+}
+
+func (s OrderScope) String() string {
+	return string(s)
+}
+
+// IsValid 是否为有效的订单类型
+func (s OrderScope) IsValid() bool {
+	_, ok := orderScopes.find(s)
+	return ok
+}
+
+// Validate 订单类型校验
+func (s OrderScope) Validate() error {
+	return orderScopes.validate(s)
+}
+
+// Label 返回订单类型的中文或英文名称
+func (s OrderScope) Label(lang Language) string {
+	return orderScopes.label(s, lang)
+}
+
+func (s OrderScope) MarshalJSON() ([]byte, error) {
+	return json.Marshal(string(s))
+}
+
+func (s *OrderScope) UnmarshalJSON(b []byte) error {
+	return orderScopes.unmarshalJSON(b, s)
+}
+
+var serviceTypes = enum[ServiceType]{name: "服务类型", items: []enumItem[ServiceType]{
+	{ServiceTypeEco, "特惠", "Economy"},
+	{ServiceTypeExp, "标快", "Express"},
+}}
+
+// ServiceTypes 返回所有服务类型
+func ServiceTypes() []ServiceType {
+	return serviceTypes.values()
+}
+
+// ParseServiceType 解析服务类型，不区分大小写，也可以使用中英文标签（比如“标快”）
+func ParseServiceType(s string) (ServiceType, error) {
+	return serviceTypes.parse(s)
+}
+
+func (t ServiceType) String() string {
+	return string(t)
+}
+
+// IsValid 是否为有效的服务类型
+func (t ServiceType) IsValid() bool {
+	_, ok := serviceTypes.find(t)
+	return ok
+}
+
+// Validate 服务类型校验
+func (t ServiceType) Validate() error {
+	return serviceTypes.validate(t)
+}
+
+// Label 返回服务类型的中文或英文名称
+func (t ServiceType) Label(lang Language) string {
+	return serviceTypes.label(t, lang)
+}
+
+func (t ServiceType) MarshalJSON() ([]byte, error) {
+	return json.Marshal(string(t))
+}
+
+func (t *ServiceType) UnmarshalJSON(b []byte) error {
+	return serviceTypes.unmarshalJSON(b, t)
+}
+
+var deliveryMethods = enum[DeliveryMethod]{name: "送货方式", items: []enumItem[DeliveryMethod]{
+	{DeliveryMethodHdy, "上门派送", "Home Delivery"},
+	{DeliveryMethodSpu, "自提", "Self Pickup"},
+}}
+
+// DeliveryMethods 返回所有送货方式
+func DeliveryMethods() []DeliveryMethod {
+	return deliveryMethods.values()
+}
+
+// ParseDeliveryMethod 解析送货方式，不区分大小写，也可以使用中英文标签（比如“自提”）
+func ParseDeliveryMethod(s string) (DeliveryMethod, error) {
+	return deliveryMethods.parse(s)
+}
+
+func (m DeliveryMethod) String() string {
+	return string(m)
+}
+
+// IsValid 是否为有效的送货方式
+func (m DeliveryMethod) IsValid() bool {
+	_, ok := deliveryMethods.find(m)
+	return ok
+}
+
+// Validate 送货方式校验
+func (m DeliveryMethod) Validate() error {
+	return deliveryMethods.validate(m)
+}
+
+// Label 返回送货方式的中文或英文名称
+func (m DeliveryMethod) Label(lang Language) string {
+	return deliveryMethods.label(m, lang)
+}
+
+func (m DeliveryMethod) MarshalJSON() ([]byte, error) {
+	return json.Marshal(string(m))
+}
+
+func (m *DeliveryMethod) UnmarshalJSON(b []byte) error {
+	return deliveryMethods.unmarshalJSON(b, m)
+}
+
+var cooperationMethods = enum[CooperationMethod]{name: "合作方式", items: []enumItem[CooperationMethod]{
+	{CooperationMethodPlatform, "平台", "Platform"},
+	{CooperationMethodMerchant, "商家", "Merchant"},
+	{CooperationMethodWesternPost, "西邮", "Western Post"},
+}}
+
+// CooperationMethods 返回所有合作方式
+func CooperationMethods() []CooperationMethod {
+	return cooperationMethods.values()
+}
+
+// ParseCooperationMethod 解析合作方式，不区分大小写，也可以使用中英文标签（比如“商家”）
+func ParseCooperationMethod(s string) (CooperationMethod, error) {
+	return cooperationMethods.parse(s)
+}
+
+func (m CooperationMethod) String() string {
+	return string(m)
+}
+
+// IsValid 是否为有效的合作方式
+func (m CooperationMethod) IsValid() bool {
+	_, ok := cooperationMethods.find(m)
+	return ok
+}
+
+// Validate 合作方式校验
+func (m CooperationMethod) Validate() error {
+	return cooperationMethods.validate(m)
+}
+
+// Label 返回合作方式的中文或英文名称
+func (m CooperationMethod) Label(lang Language) string {
+	return cooperationMethods.label(m, lang)
+}
+
+func (m CooperationMethod) MarshalJSON() ([]byte, error) {
+	return json.Marshal(string(m))
+}
+
+func (m *CooperationMethod) UnmarshalJSON(b []byte) error {
+	return cooperationMethods.unmarshalJSON(b, m)
+}
@@ -0,0 +1,39 @@
+package entity
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEnum(t *testing.T) {
+	assert.Equal(t, []ServiceType{ServiceTypeEco, ServiceTypeExp}, ServiceTypes())
+	assert.True(t, OrderScopeDomestic.IsValid())
+	assert.False(t, OrderScope("LOCAL").IsValid())
+	assert.Equal(t, "标快", ServiceTypeExp.Label(LanguageZh))
+	assert.Equal(t, "Self Pickup", DeliveryMethodSpu.Label(LanguageEn))
+	assert.Equal(t, "UNKNOWN", CooperationMethod("UNKNOWN").Label(LanguageZh))
+	assert.EqualError(t, CooperationMethod("SHOP").Validate(), "无效的合作方式 SHOP，可选值：PLATFORM、MERCHANT、WESTERN_POST")
+
+	for _, s := range []string{"western_post", "西邮", "Western Post"} {
+		v, err := ParseCooperationMethod(s)
+		assert.NoError(t, err)
+		assert.Equal(t, CooperationMethodWesternPost, v)
+	}
+	_, err := ParseOrderScope("LOCAL")
+	assert.Error(t, err)
+
+	var v struct {
+		OrderScope  OrderScope  `json:"orderScope"`
+		ServiceType ServiceType `json:"serviceType"`
+	}
+	assert.NoError(t, json.Unmarshal([]byte(`{"orderScope":"domestic","serviceType":""}`), &v))
+	assert.Equal(t, OrderScopeDomestic, v.OrderScope)
+	assert.Equal(t, ServiceType(""), v.ServiceType)
+	assert.Error(t, json.Unmarshal([]byte(`{"orderScope":"LOCAL"}`), &v))
+	assert.Error(t, json.Unmarshal([]byte(`{"orderScope":1}`), &v))
+	b, err := json.Marshal(v)
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"orderScope":"DOMESTIC","serviceType":""}`, string(b))
+}
@@ -0,0 +1,364 @@
+package entity
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+// Currency 币种代码（ISO 4217）
+type Currency string
+
+// SwiftX 支持的币种
+const (
+	CurrencyUSD Currency = "USD" // 美元
+	CurrencyCAD Currency = "CAD" // 加元
+	CurrencyHKD Currency = "HKD" // 港币
+	CurrencyCNY Currency = "CNY" // 人民币
+)
+
+var currencies = enum[Currency]{name: "币种代码", items: []enumItem[Currency]{
+	{CurrencyUSD, "美元", "US Dollar"},
+	{CurrencyCAD, "加元", "Canadian Dollar"},
+	{CurrencyHKD, "港币", "Hong Kong Dollar"},
+	{CurrencyCNY, "人民币", "Chinese Yuan"},
+}}
+
+// currencyDigits 各币种最小货币单位的小数位数，未列出的币种使用 defaultCurrencyDigits
+var currencyDigits = map[Currency]int{
+	CurrencyUSD: 2,
+	CurrencyCAD: 2,
+	CurrencyHKD: 2,
+	CurrencyCNY: 2,
+}
+
+const defaultCurrencyDigits = 2
+
+// Currencies 返回 SwiftX 支持的所有币种
+func Currencies() []Currency {
+	return currencies.values()
+}
+
+// ParseCurrency 解析币种代码，不区分大小写
+func ParseCurrency(s string) (Currency, error) {
+	return currencies.parse(s)
+}
+
+func (c Currency) String() string {
+	return string(c)
+}
+
+// IsValid 是否为 SwiftX 支持的币种
+func (c Currency) IsValid() bool {
+	_, ok := currencies.find(c)
+	return ok
+}
+
+// Validate 币种代码校验
+func (c Currency) Validate() error {
+	return currencies.validate(c)
+}
+
+// Label 返回币种的中文或英文名称
+func (c Currency) Label(lang Language) string {
+	return currencies.label(c, lang)
+}
+
+// Digits 返回最小货币单位的小数位数，比如美元为 2（1 美元 = 100 美分）
+func (c Currency) Digits() int {
+	if digits, ok := currencyDigits[c]; ok {
+		return digits
+	}
+	return defaultCurrencyDigits
+}
+
+func (c Currency) MarshalJSON() ([]byte, error) {
+	return json.Marshal(string(c))
+}
+
+// UnmarshalJSON 解析币种代码，为兼容接口返回的其他币种，不支持的币种不会返回错误，由 Validate 校验
+func (c *Currency) UnmarshalJSON(b []byte) error {
+	var s string
+	if err := json.Unmarshal(b, &s); err != nil {
+		return fmt.Errorf("无效的币种代码 %s", b)
+	}
+	*c = Currency(strings.ToUpper(strings.TrimSpace(s)))
+	return nil
+}
+
+var (
+	// ErrCurrencyMismatch 不同币种的金额不能直接计算
+	ErrCurrencyMismatch = errors.New("币种不一致")
+	// ErrMoneyOverflow 金额超出 int64 最小货币单位的表示范围
+	ErrMoneyOverflow = errors.New("金额超出范围")
+)
+
+// Money 金额，使用最小货币单位（比如美分）的整数表示，避免浮点数计算产生的误差
+//
+// 小数转换为金额时按币种的小数位数四舍五入（0.5 远离 0 舍入）。
+// JSON 格式与 SwiftX 接口一致：{"currencyCode":"USD","value":12.5}
+type Money struct {
+	Amount   int64    // 金额，单位为最小货币单位
+	Currency Currency // 币种
+}
+
+// NewMoney 使用最小货币单位的金额创建 Money，比如 NewMoney(1250, CurrencyUSD) 表示 12.50 美元
+func NewMoney(amount int64, currency Currency) Money {
+	return Money{Amount: amount, Currency: currency}
+}
+
+// ParseMoney 解析小数形式的金额，比如 "12.5"，超过币种小数位数的部分四舍五入
+func ParseMoney(s string, currency Currency) (Money, error) {
+	r, ok := new(big.Rat).SetString(strings.TrimSpace(s))
+	if !ok {
+		return Money{}, fmt.Errorf("无效的金额 %s", s)
+	}
+	amount, err := roundRat(r, currency.Digits())
+	if err != nil {
+		return Money{}, fmt.Errorf("无效的金额 %s：%w", s, err)
+	}
+	return Money{Amount: amount, Currency: currency}, nil
+}
+
+// MustParseMoney 与 ParseMoney 相同，解析失败时 panic，用于常量金额
+func MustParseMoney(s string, currency Currency) Money {
+	m, err := ParseMoney(s, currency)
+	if err != nil {
+		panic(err)
+	}
+	return m
+}
+
+// MoneyFromRat 将精确小数转换为 Money，超过币种小数位数的部分四舍五入
+func MoneyFromRat(r *big.Rat, currency Currency) (Money, error) {
+	amount, err := roundRat(r, currency.Digits())
+	if err != nil {
+		return Money{}, err
+	}
+	return Money{Amount: amount, Currency: currency}, nil
+}
+
+// MoneyFromFloat 将浮点数金额转换为 Money，按浮点数的最短十进制表示（比如 1.005）四舍五入，
+// NaN、Inf 和超出范围的金额返回错误
+func MoneyFromFloat(f float64, currency Currency) (Money, error) {
+	if math.IsNaN(f) || math.IsInf(f, 0) {
+		return Money{}, fmt.Errorf("无效的金额 %v", f)
+	}
+	return ParseMoney(strconv.FormatFloat(f, 'f', -1, 64), currency)
+}
+
+// MustMoneyFromFloat 与 MoneyFromFloat 相同，转换失败时 panic，用于常量金额
+func MustMoneyFromFloat(f float64, currency Currency) Money {
+	m, err := MoneyFromFloat(f, currency)
+	if err != nil {
+		panic(err)
+	}
+	return m
+}
+
+// roundRat 将 r 转换为 digits 位小数的最小单位整数，0.5 远离 0 舍入
+func roundRat(r *big.Rat, digits int) (int64, error) {
+	scaled := new(big.Rat).Mul(r, new(big.Rat).SetInt(new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(digits)), nil)))
+	quo, rem := new(big.Int).QuoRem(scaled.Num(), scaled.Denom(), new(big.Int))
+	// |rem| * 2 >= denom 时远离 0 舍入
+	if rem.Sign() != 0 && new(big.Int).Mul(new(big.Int).Abs(rem), big.NewInt(2)).Cmp(scaled.Denom()) >= 0 {
+		if scaled.Sign() < 0 {
+			quo.Sub(quo, big.NewInt(1))
+		} else {
+			quo.Add(quo, big.NewInt(1))
+		}
+	}
+	if !quo.IsInt64() {
+		return 0, ErrMoneyOverflow
+	}
+	return quo.Int64(), nil
+}
+
+// Rat 返回金额的精确小数值
+func (m Money) Rat() *big.Rat {
+	return new(big.Rat).SetFrac(big.NewInt(m.Amount), new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(m.Currency.Digits())), nil))
+}
+
+// Float64 返回浮点数形式的金额，仅用于展示，不要用于计算
+func (m Money) Float64() float64 {
+	f, _ := m.Rat().Float64()
+	return f
+}
+
+// Decimal 返回固定小数位数的金额，比如 "12.50"
+func (m Money) Decimal() string {
+	return m.Rat().FloatString(m.Currency.Digits())
+}
+
+// String 返回金额和币种，比如 "12.50 USD"
+func (m Money) String() string {
+	if m.Currency == "" {
+		return m.Decimal()
+	}
+	return m.Decimal() + " " + string(m.Currency)
+}
+
+// IsZero 金额是否为 0
+func (m Money) IsZero() bool {
+	return m.Amount == 0
+}
+
+// IsNegative 金额是否小于 0
+func (m Money) IsNegative() bool {
+	return m.Amount < 0
+}
+
+// Add 返回 m + o，币种不一致时返回 ErrCurrencyMismatch，溢出时返回 ErrMoneyOverflow
+func (m Money) Add(o Money) (Money, error) {
+	if m.Currency != o.Currency {
+		return Money{}, fmt.Errorf("%w：%s、%s", ErrCurrencyMismatch, m.Currency, o.Currency)
+	}
+	amount := m.Amount + o.Amount
+	if (o.Amount > 0 && amount < m.Amount) || (o.Amount < 0 && amount > m.Amount) {
+		return Money{}, fmt.Errorf("%w：%s + %s", ErrMoneyOverflow, m, o)
+	}
+	return Money{Amount: amount, Currency: m.Currency}, nil
+}
+
+// Sub 返回 m - o，币种不一致时返回 ErrCurrencyMismatch，溢出时返回 ErrMoneyOverflow
+func (m Money) Sub(o Money) (Money, error) {
+	if m.Currency != o.Currency {
+		return Money{}, fmt.Errorf("%w：%s、%s", ErrCurrencyMismatch, m.Currency, o.Currency)
+	}
+	amount := m.Amount - o.Amount
+	if (o.Amount > 0 && amount > m.Amount) || (o.Amount < 0 && amount < m.Amount) {
+		return Money{}, fmt.Errorf("%w：%s - %s", ErrMoneyOverflow, m, o)
+	}
+	return Money{Amount: amount, Currency: m.Currency}, nil
+}
+
+// Mul 返回 m * n，比如单价乘以数量，溢出时返回 ErrMoneyOverflow
+func (m Money) Mul(n int64) (Money, error) {
+	amount := m.Amount * n
+	if m.Amount != 0 && (amount/m.Amount != n || (m.Amount == -1 && n == math.MinInt64)) {
+		return Money{}, fmt.Errorf("%w：%s × %d", ErrMoneyOverflow, m, n)
+	}
+	return Money{Amount: amount, Currency: m.Currency}, nil
+}
+
+// MulRat 返回 m * r，结果按币种的小数位数四舍五入
+func (m Money) MulRat(r *big.Rat) (Money, error) {
+	amount, err := roundRat(new(big.Rat).Mul(m.Rat(), r), m.Currency.Digits())
+	if err != nil {
+		return Money{}, err
+	}
+	return Money{Amount: amount, Currency: m.Currency}, nil
+}
+
+// Cmp 比较金额，m < o 时返回 -1，相等返回 0，m > o 返回 1，币种不一致时返回 ErrCurrencyMismatch
+func (m Money) Cmp(o Money) (int, error) {
+	if m.Currency != o.Currency {
+		return 0, fmt.Errorf("%w：%s、%s", ErrCurrencyMismatch, m.Currency, o.Currency)
+	}
+	switch {
+	case m.Amount < o.Amount:
+		return -1, nil
+	case m.Amount > o.Amount:
+		return 1, nil
+	}
+	return 0, nil
+}
+
+// SumMoney 返回金额合计，currency 为合计的币种，所有金额的币种必须与其一致
+func SumMoney(currency Currency, items ...Money) (Money, error) {
+	sum := Money{Currency: currency}
+	for _, item := range items {
+		var err error
+		if sum, err = sum.Add(item); err != nil {
+			return Money{}, err
+		}
+	}
+	return sum, nil
+}
+
+// MarshalNumber 返回 JSON 数字形式的金额（去掉小数末尾的 0），比如 12.5
+func (m Money) MarshalNumber() json.Number {
+	s := m.Decimal()
+	if strings.Contains(s, ".") {
+		s = strings.TrimRight(strings.TrimRight(s, "0"), ".")
+	}
+	return json.Number(s)
+}
+
+// UnmarshalNumber 解析 JSON 数字形式的金额，使用 m.Currency 的小数位数
+func (m *Money) UnmarshalNumber(b []byte) error {
+	b = bytes.TrimSpace(b)
+	if len(b) == 0 || bytes.Equal(b, []byte("null")) {
+		m.Amount = 0
+		return nil
+	}
+	s := string(b)
+	if b[0] == '"' {
+		// 兼容字符串形式的金额
+		if err := json.Unmarshal(b, &s); err != nil {
+			return err
+		}
+	}
+	v, err := ParseMoney(s, m.Currency)
+	if err != nil {
+		return err
+	}
+	m.Amount = v.Amount
+	return nil
+}
+
+type moneyJSON struct {
+	CurrencyCode Currency        `json:"currencyCode"`
+	Value        json.RawMessage `json:"value"`
+}
+
+func (m Money) MarshalJSON() ([]byte, error) {
+	return json.Marshal(moneyJSON{CurrencyCode: m.Currency, Value: json.RawMessage(m.MarshalNumber())})
+}
+
+func (m *Money) UnmarshalJSON(b []byte) error {
+	var v moneyJSON
+	if err := json.Unmarshal(b, &v); err != nil {
+		return err
+	}
+	// 币种需要先确定，金额按币种的小数位数解析
+	m.Currency = v.CurrencyCode
+	return m.UnmarshalNumber(v.Value)
+}
+
+// NullMoney 可以为空的金额
+type NullMoney struct {
+	Money
+	Valid bool // 是否有值
+}
+
+// NullMoneyFrom 返回有值的 NullMoney
+func NullMoneyFrom(m Money) NullMoney {
+	return NullMoney{Money: m, Valid: true}
+}
+
+// MarshalJSON 没有值时输出 null，否则与 Money 相同
+func (m NullMoney) MarshalJSON() ([]byte, error) {
+	if !m.Valid {
+		return []byte("null"), nil
+	}
+	return m.Money.MarshalJSON()
+}
+
+// UnmarshalJSON null 表示没有值，否则按 Money 解析
+func (m *NullMoney) UnmarshalJSON(b []byte) error {
+	if bytes.Equal(bytes.TrimSpace(b), []byte("null")) {
+		*m = NullMoney{}
+		return nil
+	}
+	if err := m.Money.UnmarshalJSON(b); err != nil {
+		return err
+	}
+	m.Valid = true
+	return nil
+}
@@ -0,0 +1,143 @@
+package entity
+
+import (
+	"encoding/json"
+	"errors"
+	"math"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseMoney(t *testing.T) {
+	tests := []struct {
+		s      string
+		amount int64
+	}{
+		{"12.5", 1250},
+		{"0.1", 10},
+		{"1.005", 101},
+		{"1.004", 100},
+		{"-1.005", -101},
+		{"1e2", 10000},
+	}
+	for _, test := range tests {
+		m, err := ParseMoney(test.s, CurrencyUSD)
+		assert.NoError(t, err, test.s)
+		assert.Equal(t, test.amount, m.Amount, test.s)
+	}
+	_, err := ParseMoney("abc", CurrencyUSD)
+	assert.Error(t, err)
+	_, err = ParseMoney("1e30", CurrencyUSD)
+	assert.Error(t, err)
+
+	assert.Equal(t, int64(101), MustMoneyFromFloat(1.005, CurrencyUSD).Amount)
+	for _, f := range []float64{math.NaN(), math.Inf(1), math.Inf(-1), 1e30} {
+		_, err = MoneyFromFloat(f, CurrencyUSD)
+		assert.Error(t, err, f)
+	}
+	assert.Panics(t, func() { MustMoneyFromFloat(math.NaN(), CurrencyUSD) })
+	assert.Equal(t, "12.50 USD", MustParseMoney("12.5", CurrencyUSD).String())
+}
+
+func TestMoney_Arithmetic(t *testing.T) {
+	// 浮点数累加 0.1 十次不等于 1
+	sum := Money{Currency: CurrencyUSD}
+	for i := 0; i < 10; i++ {
+		var err error
+		sum, err = sum.Add(MustParseMoney("0.1", CurrencyUSD))
+		assert.NoError(t, err)
+	}
+	assert.Equal(t, MustParseMoney("1", CurrencyUSD), sum)
+
+	price, err := NewMoney(1999, CurrencyCNY).Mul(3)
+	assert.NoError(t, err)
+	total, err := SumMoney(CurrencyCNY, price, NewMoney(1, CurrencyCNY))
+	assert.NoError(t, err)
+	assert.Equal(t, "59.98", total.Decimal())
+
+	_, err = total.Add(NewMoney(1, CurrencyUSD))
+	assert.True(t, errors.Is(err, ErrCurrencyMismatch))
+	_, err = total.Cmp(NewMoney(1, CurrencyUSD))
+	assert.True(t, errors.Is(err, ErrCurrencyMismatch))
+	c, err := total.Cmp(NewMoney(1, CurrencyCNY))
+	assert.NoError(t, err)
+	assert.Equal(t, 1, c)
+
+	diff, err := NewMoney(100, CurrencyHKD).Sub(NewMoney(250, CurrencyHKD))
+	assert.NoError(t, err)
+	assert.True(t, diff.IsNegative())
+
+	m, err := NewMoney(1000, CurrencyUSD).MulRat(big.NewRat(1, 3))
+	assert.NoError(t, err)
+	assert.Equal(t, int64(333), m.Amount)
+}
+
+func TestMoney_Overflow(t *testing.T) {
+	maxMoney := NewMoney(math.MaxInt64, CurrencyUSD)
+	minMoney := NewMoney(math.MinInt64, CurrencyUSD)
+	one := NewMoney(1, CurrencyUSD)
+
+	_, err := maxMoney.Add(one)
+	assert.ErrorIs(t, err, ErrMoneyOverflow)
+	_, err = minMoney.Add(NewMoney(-1, CurrencyUSD))
+	assert.ErrorIs(t, err, ErrMoneyOverflow)
+	_, err = minMoney.Sub(one)
+	assert.ErrorIs(t, err, ErrMoneyOverflow)
+	_, err = maxMoney.Sub(NewMoney(-1, CurrencyUSD))
+	assert.ErrorIs(t, err, ErrMoneyOverflow)
+	_, err = NewMoney(math.MaxInt64/2+1, CurrencyUSD).Mul(2)
+	assert.ErrorIs(t, err, ErrMoneyOverflow)
+	_, err = minMoney.Mul(-1)
+	assert.ErrorIs(t, err, ErrMoneyOverflow)
+	_, err = NewMoney(-1, CurrencyUSD).Mul(math.MinInt64)
+	assert.ErrorIs(t, err, ErrMoneyOverflow)
+	_, err = SumMoney(CurrencyUSD, maxMoney, one)
+	assert.ErrorIs(t, err, ErrMoneyOverflow)
+
+	m, err := maxMoney.Add(NewMoney(-1, CurrencyUSD))
+	assert.NoError(t, err)
+	assert.Equal(t, int64(math.MaxInt64-1), m.Amount)
+	m, err = minMoney.Mul(1)
+	assert.NoError(t, err)
+	assert.Equal(t, minMoney, m)
+	m, err = NewMoney(0, CurrencyUSD).Mul(math.MinInt64)
+	assert.NoError(t, err)
+	assert.True(t, m.IsZero())
+}
+
+func TestMoney_JSON(t *testing.T) {
+	b, err := json.Marshal(MustParseMoney("12.50", CurrencyUSD))
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"currencyCode":"USD","value":12.5}`, string(b))
+
+	var m Money
+	assert.NoError(t, json.Unmarshal([]byte(`{"currencyCode":"usd","value":0.30000000000000004}`), &m))
+	assert.Equal(t, NewMoney(30, CurrencyUSD), m)
+	assert.NoError(t, json.Unmarshal([]byte(`{"currencyCode":"EUR","value":"8"}`), &m))
+	assert.Equal(t, NewMoney(800, "EUR"), m)
+	assert.Error(t, m.Currency.Validate())
+	assert.Error(t, json.Unmarshal([]byte(`{"currencyCode":"USD","value":"abc"}`), &m))
+}
+
+func TestNullMoney_JSON(t *testing.T) {
+	var v struct {
+		Price NullMoney `json:"price"`
+	}
+	b, err := json.Marshal(v)
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"price":null}`, string(b))
+
+	v.Price = NullMoneyFrom(MustParseMoney("19.99", CurrencyUSD))
+	b, err = json.Marshal(v)
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"price":{"currencyCode":"USD","value":19.99}}`, string(b))
+
+	assert.NoError(t, json.Unmarshal([]byte(`{"price":{"currencyCode":"CNY","value":8}}`), &v))
+	assert.Equal(t, NullMoneyFrom(NewMoney(800, CurrencyCNY)), v.Price)
+	assert.NoError(t, json.Unmarshal([]byte(`{"price":null}`), &v))
+	assert.Equal(t, NullMoney{}, v.Price)
+	assert.False(t, v.Price.Valid)
+	assert.Error(t, json.Unmarshal([]byte(`{"price":{"currencyCode":"CNY","value":"abc"}}`), &v))
+}
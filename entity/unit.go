@@ -0,0 +1,177 @@
+package entity
+
+import (
+	"fmt"
+	"strconv"
+)
+
+// 换算系数
+const (
+	kilogramsPerPound  = 0.45359237
+	centimetersPerInch = 2.54
+)
+
+// WeightUnit 重量单位，lb 以外的值（包括空值）均按公斤处理
+type WeightUnit string
+
+const (
+	WeightUnitKg WeightUnit = "kg" // 公斤
+	WeightUnitLb WeightUnit = "lb" // 磅
+)
+
+// IsImperial 是否为英制单位（磅）
+func (u WeightUnit) IsImperial() bool {
+	return u == WeightUnitLb
+}
+
+// LengthUnit 长度单位，in 以外的值（包括空值）均按厘米处理
+type LengthUnit string
+
+const (
+	LengthUnitCm LengthUnit = "cm" // 厘米
+	LengthUnitIn LengthUnit = "in" // 英寸
+)
+
+// IsImperial 是否为英制单位（英寸）
+func (u LengthUnit) IsImperial() bool {
+	return u == LengthUnitIn
+}
+
+// UnitSystem 单位制
+type UnitSystem string
+
+const (
+	UnitSystemMetric   UnitSystem = "metric"   // 公制（公斤、厘米）
+	UnitSystemImperial UnitSystem = "imperial" // 英制（磅、英寸）
+)
+
+// UnitSystemOf 根据 CreateOrderPackageInformation.UseImperialUnit 返回单位制
+func UnitSystemOf(useImperialUnit bool) UnitSystem {
+	if useImperialUnit {
+		return UnitSystemImperial
+	}
+	return UnitSystemMetric
+}
+
+// IsImperial 是否为英制
+func (s UnitSystem) IsImperial() bool {
+	return s == UnitSystemImperial
+}
+
+// WeightUnit 返回单位制的重量单位
+func (s UnitSystem) WeightUnit() WeightUnit {
+	if s.IsImperial() {
+		return WeightUnitLb
+	}
+	return WeightUnitKg
+}
+
+// LengthUnit 返回单位制的长度单位
+func (s UnitSystem) LengthUnit() LengthUnit {
+	if s.IsImperial() {
+		return LengthUnitIn
+	}
+	return LengthUnitCm
+}
+
+// Weight 重量
+type Weight struct {
+	Value float64    // 数值
+	Unit  WeightUnit // 单位，为空时为公斤
+}
+
+// Kilograms 返回以公斤为单位的重量
+func Kilograms(v float64) Weight {
+	return Weight{Value: v, Unit: WeightUnitKg}
+}
+
+// Pounds 返回以磅为单位的重量
+func Pounds(v float64) Weight {
+	return Weight{Value: v, Unit: WeightUnitLb}
+}
+
+// In 转换为 unit 单位的重量，返回的单位为 kg 或 lb
+func (w Weight) In(unit WeightUnit) Weight {
+	v := w.Value
+	switch {
+	case w.Unit.IsImperial() == unit.IsImperial():
+	case unit.IsImperial():
+		v /= kilogramsPerPound
+	default:
+		v *= kilogramsPerPound
+	}
+	if unit.IsImperial() {
+		return Pounds(v)
+	}
+	return Kilograms(v)
+}
+
+// Kilograms 返回公斤数
+func (w Weight) Kilograms() float64 {
+	return w.In(WeightUnitKg).Value
+}
+
+// Pounds 返回磅数
+func (w Weight) Pounds() float64 {
+	return w.In(WeightUnitLb).Value
+}
+
+// String 返回数值和单位，比如 "1.5 kg"，空单位和未知单位按公斤输出
+func (w Weight) String() string {
+	unit := WeightUnitKg
+	if w.Unit.IsImperial() {
+		unit = WeightUnitLb
+	}
+	return fmt.Sprintf("%s %s", strconv.FormatFloat(w.Value, 'f', -1, 64), unit)
+}
+
+// Length 长度
+type Length struct {
+	Value float64    // 数值
+	Unit  LengthUnit // 单位，为空时为厘米
+}
+
+// Centimeters 返回以厘米为单位的长度
+func Centimeters(v float64) Length {
+	return Length{Value: v, Unit: LengthUnitCm}
+}
+
+// Inches 返回以英寸为单位的长度
+func Inches(v float64) Length {
+	return Length{Value: v, Unit: LengthUnitIn}
+}
+
+// In 转换为 unit 单位的长度，返回的单位为 cm 或 in
+func (l Length) In(unit LengthUnit) Length {
+	v := l.Value
+	switch {
+	case l.Unit.IsImperial() == unit.IsImperial():
+	case unit.IsImperial():
+		v /= centimetersPerInch
+	default:
+		v *= centimetersPerInch
+	}
+	if unit.IsImperial() {
+		return Inches(v)
+	}
+	return Centimeters(v)
+}
+
+// Centimeters 返回厘米数
+func (l Length) Centimeters() float64 {
+	return l.In(LengthUnitCm).Value
+}
+
+// Inches 返回英寸数
+func (l Length) Inches() float64 {
+	return l.In(LengthUnitIn).Value
+}
+
+// String 返回数值和单位，比如 "12 in"，空单位和未知单位按厘米输出
+func (l Length) String() string {
+	unit := LengthUnitCm
+	if l.Unit.IsImperial() {
+		unit = LengthUnitIn
+	}
+	return fmt.Sprintf("%s %s", strconv.FormatFloat(l.Value, 'f', -1, 64), unit)
+}
@@ -0,0 +1,32 @@
+package entity
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUnit(t *testing.T) {
+	assert.InDelta(t, 2.20462, Kilograms(1).Pounds(), 0.00001)
+	assert.InDelta(t, 0.45359237, Pounds(1).Kilograms(), 1e-12)
+	assert.Equal(t, Pounds(3), Pounds(3).In(WeightUnitLb))
+	assert.InDelta(t, 25.4, Inches(10).Centimeters(), 1e-9)
+	assert.InDelta(t, 10, Centimeters(25.4).Inches(), 1e-9)
+	assert.Equal(t, "1.5 kg", Kilograms(1.5).String())
+	assert.Equal(t, "12 in", Inches(12).String())
+	assert.Equal(t, "1.5 kg", Weight{Value: 1.5}.String())
+	assert.Equal(t, "12 cm", Length{Value: 12}.String())
+
+	// 空单位和无效单位按公制单位处理，转换方向不影响结果
+	assert.Equal(t, Kilograms(2), Weight{Value: 2}.In(WeightUnitKg))
+	assert.InDelta(t, 2/kilogramsPerPound, Weight{Value: 2}.Pounds(), 1e-9)
+	assert.Equal(t, Kilograms(2), Weight{Value: 2, Unit: "g"}.In(""))
+	assert.Equal(t, Kilograms(kilogramsPerPound), Pounds(1).In(""))
+	assert.Equal(t, Centimeters(10), Length{Value: 10}.In(LengthUnitCm))
+	assert.InDelta(t, 10/centimetersPerInch, Length{Value: 10}.Inches(), 1e-9)
+	assert.Equal(t, Centimeters(centimetersPerInch), Inches(1).In(""))
+
+	assert.Equal(t, UnitSystemImperial, UnitSystemOf(true))
+	assert.Equal(t, WeightUnitKg, UnitSystemOf(false).WeightUnit())
+	assert.Equal(t, LengthUnitIn, UnitSystemImperial.LengthUnit())
+}
@@ -0,0 +1,210 @@
+package swiftx
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hiscaler/swiftx-go/config"
+	"github.com/hiscaler/swiftx-go/entity"
+)
+
+// ErrRateNotFound 没有可用的汇率
+var ErrRateNotFound = errors.New("swiftx: 没有可用的汇率")
+
+// Converter 币种转换
+type Converter interface {
+	// Rate 返回 at 时 1 单位 from 币种可以兑换的 to 币种数量
+	Rate(from, to entity.Currency, at time.Time) (*big.Rat, error)
+}
+
+// ConvertMoney 将金额转换为 to 币种，结果按 to 币种的小数位数四舍五入
+func ConvertMoney(c Converter, m entity.Money, to entity.Currency, at time.Time) (entity.Money, error) {
+	if m.Currency == to {
+		return m, nil
+	}
+	rate, err := c.Rate(m.Currency, to, at)
+	if err != nil {
+		return entity.Money{}, err
+	}
+	return entity.MoneyFromRat(new(big.Rat).Mul(m.Rat(), rate), to)
+}
+
+// ConvertOrderPrice 将订单价格（总额和明细）转换为 to 币种
+//
+// 总额和明细分别转换和舍入，明细合计与总额可能有最小货币单位的差异。
+func ConvertOrderPrice(c Converter, price entity.OrderPrice, to entity.Currency, at time.Time) (entity.OrderPrice, error) {
+	amount, err := ConvertMoney(c, price.Amount, to, at)
+	if err != nil {
+		return entity.OrderPrice{}, err
+	}
+	details := make([]entity.PriceDetail, len(price.Details))
+	for i, detail := range price.Details {
+		if details[i].Cost, err = ConvertMoney(c, detail.Cost, to, at); err != nil {
+			return entity.OrderPrice{}, err
+		}
+		details[i].Description = detail.Description
+	}
+	return entity.OrderPrice{
+		TrackingNumber: price.TrackingNumber,
+		Amount:         amount,
+		Details:        details,
+	}, nil
+}
+
+// ConvertPackageValue 将包裹总价值转换为 to 币种
+func ConvertPackageValue(c Converter, info CreateOrderPackageInformation, to entity.Currency, at time.Time) (entity.Money, error) {
+	return ConvertMoney(c, info.Value.Money, to, at)
+}
+
+// ConvertSkuValueTotal 将 SKU 单价乘以数量后转换为 to 币种并合计，SKU 可以使用不同的币种，
+// 未设置币种的 SKU 使用包裹总价值的币种，未设置单价的 SKU 不计入合计
+func ConvertSkuValueTotal(c Converter, info CreateOrderPackageInformation, to entity.Currency, at time.Time) (entity.Money, error) {
+	total := entity.Money{Currency: to}
+	for _, sku := range info.SkuList {
+		if !sku.Value.Valid {
+			continue
+		}
+		value := sku.Value.Money
+		if value.Currency == "" {
+			value.Currency = info.Value.Currency
+		}
+		amount, err := value.Mul(int64(sku.Quantity))
+		if err != nil {
+			return entity.Money{}, err
+		}
+		converted, err := ConvertMoney(c, amount, to, at)
+		if err != nil {
+			return entity.Money{}, err
+		}
+		if total, err = total.Add(converted); err != nil {
+			return entity.Money{}, err
+		}
+	}
+	return total, nil
+}
+
+// crossRate 根据相对于同一基准币种的汇率计算 from 兑换 to 的汇率
+func crossRate(base entity.Currency, rates map[entity.Currency]*big.Rat, from, to entity.Currency) (*big.Rat, error) {
+	rate := func(currency entity.Currency) (*big.Rat, bool) {
+		if currency == base {
+			return big.NewRat(1, 1), true
+		}
+		r, ok := rates[currency]
+		return r, ok
+	}
+	fromRate, ok := rate(from)
+	if !ok {
+		return nil, fmt.Errorf("%w：%s", ErrRateNotFound, from)
+	}
+	toRate, ok := rate(to)
+	if !ok {
+		return nil, fmt.Errorf("%w：%s", ErrRateNotFound, to)
+	}
+	return new(big.Rat).Quo(toRate, fromRate), nil
+}
+
+// parseRates 解析汇率，汇率必须大于 0
+func parseRates(rates map[entity.Currency]json.Number) (map[entity.Currency]*big.Rat, error) {
+	parsed := make(map[entity.Currency]*big.Rat, len(rates))
+	for currency, rate := range rates {
+		r, ok := new(big.Rat).SetString(rate.String())
+		if !ok || r.Sign() <= 0 {
+			return nil, fmt.Errorf("swiftx: 无效的 %s 汇率 %s", currency, rate)
+		}
+		parsed[entity.Currency(strings.ToUpper(string(currency)))] = r
+	}
+	return parsed, nil
+}
+
+// StaticRates 固定汇率表，不区分日期
+type StaticRates struct {
+	base  entity.Currency
+	rates map[entity.Currency]*big.Rat
+}
+
+// NewStaticRates 创建固定汇率表，rates 为 1 单位 base 币种可以兑换的各币种数量，比如 {CNY: 7.12}
+func NewStaticRates(base entity.Currency, rates map[entity.Currency]float64) (*StaticRates, error) {
+	numbers := make(map[entity.Currency]json.Number, len(rates))
+	for currency, rate := range rates {
+		numbers[currency] = json.Number(strconv.FormatFloat(rate, 'f', -1, 64))
+	}
+	parsed, err := parseRates(numbers)
+	if err != nil {
+		return nil, err
+	}
+	return &StaticRates{base: base, rates: parsed}, nil
+}
+
+func (r *StaticRates) Rate(from, to entity.Currency, _ time.Time) (*big.Rat, error) {
+	return crossRate(r.base, r.rates, from, to)
+}
+
+// DailyRates 按日期区分的汇率表
+//
+// 查询某一天的汇率时，如果当天没有汇率（比如周末、节假日），使用之前最近一天的汇率。
+type DailyRates struct {
+	base  entity.Currency
+	dates []string // 升序排列的日期
+	rates map[string]map[entity.Currency]*big.Rat
+}
+
+// dailyRatesFile 汇率文件格式
+type dailyRatesFile struct {
+	Base  entity.Currency                            `json:"base"`  // 基准币种
+	Rates map[string]map[entity.Currency]json.Number `json:"rates"` // 日期（YYYY-MM-DD）对应的汇率
+}
+
+// LoadDailyRates 从 JSON 或 YAML 文件加载每日汇率，文件格式为：
+//
+//	base: USD
+//	rates:
+//	  "2026-01-14": {CNY: 7.12, CAD: 1.36, HKD: 7.8}
+//	  "2026-01-15": {CNY: 7.11, CAD: 1.37, HKD: 7.8}
+//
+// 日期按 at 所在时区计算。
+func LoadDailyRates(filename string) (*DailyRates, error) {
+	var file dailyRatesFile
+	if err := config.DecodeFile(filename, &file); err != nil {
+		return nil, fmt.Errorf("swiftx: 解析汇率文件 %s 失败：%w", filename, err)
+	}
+	if file.Base == "" {
+		return nil, fmt.Errorf("swiftx: 汇率文件 %s 缺少基准币种", filename)
+	}
+
+	rates := &DailyRates{
+		base:  file.Base,
+		dates: make([]string, 0, len(file.Rates)),
+		rates: make(map[string]map[entity.Currency]*big.Rat, len(file.Rates)),
+	}
+	for date, dayRates := range file.Rates {
+		var err error
+		if _, err = time.Parse(time.DateOnly, date); err != nil {
+			return nil, fmt.Errorf("swiftx: 无效的汇率日期 %s", date)
+		}
+		if rates.rates[date], err = parseRates(dayRates); err != nil {
+			return nil, err
+		}
+		rates.dates = append(rates.dates, date)
+	}
+	sort.Strings(rates.dates)
+	return rates, nil
+}
+
+func (r *DailyRates) Rate(from, to entity.Currency, at time.Time) (*big.Rat, error) {
+	date := at.Format(time.DateOnly)
+	i := sort.SearchStrings(r.dates, date)
+	if i == len(r.dates) || r.dates[i] != date {
+		// 使用之前最近一天的汇率
+		i--
+	}
+	if i < 0 {
+		return nil, fmt.Errorf("%w：%s", ErrRateNotFound, date)
+	}
+	return crossRate(r.base, r.rates[r.dates[i]], from, to)
+}
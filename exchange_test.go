@@ -0,0 +1,91 @@
+package swiftx
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/hiscaler/swiftx-go/entity"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStaticRates(t *testing.T) {
+	rates, err := NewStaticRates(entity.CurrencyUSD, map[entity.Currency]float64{entity.CurrencyCNY: 7.2, entity.CurrencyHKD: 7.8})
+	require.NoError(t, err)
+
+	m, err := ConvertMoney(rates, entity.MustParseMoney("10", entity.CurrencyUSD), entity.CurrencyCNY, time.Now())
+	assert.NoError(t, err)
+	assert.Equal(t, "72.00 CNY", m.String())
+	m, err = ConvertMoney(rates, entity.MustParseMoney("100", entity.CurrencyCNY), entity.CurrencyHKD, time.Now())
+	assert.NoError(t, err)
+	assert.Equal(t, "108.33 HKD", m.String())
+	_, err = ConvertMoney(rates, entity.MustParseMoney("1", entity.CurrencyCAD), entity.CurrencyUSD, time.Now())
+	assert.ErrorIs(t, err, ErrRateNotFound)
+
+	price, err := ConvertOrderPrice(rates, entity.OrderPrice{
+		TrackingNumber: "SWX1",
+		Amount:         entity.MustParseMoney("12.5", entity.CurrencyUSD),
+		Details:        []entity.PriceDetail{{Cost: entity.MustParseMoney("12.5", entity.CurrencyUSD), Description: "freight"}},
+	}, entity.CurrencyCNY, time.Now())
+	assert.NoError(t, err)
+	assert.Equal(t, "SWX1", price.TrackingNumber)
+	assert.Equal(t, "90.00 CNY", price.Amount.String())
+	assert.Equal(t, "90.00 CNY", price.Details[0].Cost.String())
+	assert.Equal(t, "freight", price.Details[0].Description)
+
+	info := CreateOrderPackageInformation{
+		Value: Value{Money: entity.MustParseMoney("20", entity.CurrencyUSD)},
+		SkuList: []CreateOrderPackageGoods{
+			{Name: "SKU 1", Quantity: 2, Value: entity.NullMoneyFrom(entity.MustParseMoney("36", entity.CurrencyCNY))},
+			{Name: "SKU 2", Quantity: 1, Value: entity.NullMoneyFrom(entity.MustParseMoney("5", ""))},
+		},
+	}
+	total, err := ConvertSkuValueTotal(rates, info, entity.CurrencyUSD, time.Now())
+	assert.NoError(t, err)
+	assert.Equal(t, "15.00 USD", total.String())
+	value, err := ConvertPackageValue(rates, info, entity.CurrencyCNY, time.Now())
+	assert.NoError(t, err)
+	assert.Equal(t, "144.00 CNY", value.String())
+
+	_, err = NewStaticRates(entity.CurrencyUSD, map[entity.Currency]float64{entity.CurrencyCNY: 0})
+	assert.Error(t, err)
+}
+
+func TestLoadDailyRates(t *testing.T) {
+	filename := filepath.Join(t.TempDir(), "rates.yaml")
+	require.NoError(t, os.WriteFile(filename, []byte(`
+base: USD
+rates:
+  "2026-01-14": {CNY: 7.12}
+  "2026-01-16": {CNY: 7.10}
+`), 0600))
+	rates, err := LoadDailyRates(filename)
+	require.NoError(t, err)
+
+	date := func(s string) time.Time {
+		d, _ := time.Parse(time.DateOnly, s)
+		return d
+	}
+	tests := []struct {
+		date   string
+		amount string
+	}{
+		{"2026-01-14", "71.20"},
+		{"2026-01-15", "71.20"}, // 使用之前最近一天的汇率
+		{"2026-01-16", "71.00"},
+		{"2026-02-01", "71.00"},
+	}
+	for _, test := range tests {
+		m, err := ConvertMoney(rates, entity.MustParseMoney("10", entity.CurrencyUSD), entity.CurrencyCNY, date(test.date))
+		assert.NoError(t, err, test.date)
+		assert.Equal(t, test.amount, m.Decimal(), test.date)
+	}
+	_, err = rates.Rate(entity.CurrencyUSD, entity.CurrencyCNY, date("2026-01-13"))
+	assert.ErrorIs(t, err, ErrRateNotFound)
+
+	require.NoError(t, os.WriteFile(filename, []byte(`{"base":"USD","rates":{"2026-13-01":{"CNY":7}}}`), 0600))
+	_, err = LoadDailyRates(filename)
+	assert.Error(t, err)
+}
@@ -0,0 +1,114 @@
+package exporter
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hiscaler/swiftx-go/entity"
+	"github.com/hiscaler/swiftx-go/response"
+	"github.com/stretchr/testify/assert"
+)
+
+var trackingResults = []entity.TrackingResult{
+	{
+		Result:     response.Result{Success: true},
+		TrackingNo: "SWX784390000000365027",
+		TrackingEventList: []entity.Track{
+			{Event: "PICKED_UP", Description: "Picked up", LocalTime: "2026-01-12 09:00:00", LocalGmtOffset: "-08:00", Location: "Ontario, CA"},
+			{Event: "DELIVERED", Description: "Delivered", LocalTime: "2026-01-13 18:30:00", LocalGmtOffset: "-06:00", Location: "Fort Worth, TX", PodImageCount: 1},
+		},
+	},
+	{
+		Result:     response.Result{Success: false, Message: "not found"},
+		TrackingNo: "SWX000",
+	},
+}
+
+func TestWriteTrackingEvents(t *testing.T) {
+	var buf bytes.Buffer
+	shanghai := time.FixedZone("CST", 8*3600)
+	err := WriteTrackingEvents(&buf, FormatCSV, trackingResults, Options{Location: shanghai})
+	assert.NoError(t, err)
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if assert.Len(t, lines, 4) {
+		assert.Equal(t, strings.Join(TrackingEventColumns, ","), lines[0])
+		assert.Equal(t, "SWX784390000000365027,true,,2026-01-13 01:00:00,2026-01-12 09:00:00,-08:00,PICKED_UP,Picked up,\"Ontario, CA\",,,,,0", lines[1])
+		assert.True(t, strings.HasPrefix(lines[3], "SWX000,false,not found,,"))
+	}
+
+	buf.Reset()
+	err = WriteTrackingEvents(&buf, FormatJSONLines, trackingResults[:1], Options{})
+	assert.NoError(t, err)
+	lines = strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if assert.Len(t, lines, 2) {
+		assert.True(t, strings.HasPrefix(lines[1], `{"tracking_no":"SWX784390000000365027","success":true,"message":"","time":"2026-01-14 00:30:00",`), lines[1])
+	}
+}
+
+func TestWriteTrackingLatest(t *testing.T) {
+	var buf bytes.Buffer
+	err := WriteTrackingLatest(&buf, FormatCSV, trackingResults, Options{})
+	assert.NoError(t, err)
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if assert.Len(t, lines, 3) {
+		assert.True(t, strings.HasPrefix(lines[1], "2,SWX784390000000365027,true,,2026-01-14 00:30:00,"), lines[1])
+		assert.Contains(t, lines[1], "DELIVERED")
+		assert.True(t, strings.HasPrefix(lines[2], "0,SWX000,false,not found,"), lines[2])
+	}
+}
+
+func TestWritePrices(t *testing.T) {
+	prices := []entity.OrderPrice{
+		{
+			TrackingNumber: "SWX1",
+			Amount:         entity.MustParseMoney("12.5", entity.CurrencyUSD),
+			Details: []entity.PriceDetail{
+				{Cost: entity.MustParseMoney("10", entity.CurrencyUSD), Description: "freight"},
+				{Cost: entity.MustParseMoney("2.5", entity.CurrencyUSD), Description: "fuel"},
+			},
+		},
+		{
+			TrackingNumber: "SWX2",
+			Amount:         entity.MustParseMoney("8", entity.CurrencyUSD),
+			Details: []entity.PriceDetail{
+				{Cost: entity.MustParseMoney("8", entity.CurrencyUSD), Description: "freight"},
+			},
+		},
+	}
+
+	var buf bytes.Buffer
+	assert.NoError(t, WritePrices(&buf, FormatCSV, prices, Options{}))
+	assert.Equal(t, "tracking_number,amount,currency_code,detail_freight,detail_fuel\nSWX1,12.50,USD,10.00,2.50\nSWX2,8.00,USD,8.00,\n", buf.String())
+
+	buf.Reset()
+	assert.NoError(t, WritePrices(&buf, FormatJSONLines, prices[1:], Options{}))
+	assert.Equal(t, `{"tracking_number":"SWX2","amount":8.00,"currency_code":"USD","detail_freight":8.00}`+"\n", buf.String())
+
+	// 超出 float64 精度的金额
+	buf.Reset()
+	large := []entity.OrderPrice{{TrackingNumber: "SWX3", Amount: entity.NewMoney(9007199254740993, entity.CurrencyUSD)}}
+	assert.NoError(t, WritePrices(&buf, FormatCSV, large, Options{}))
+	assert.Equal(t, "tracking_number,amount,currency_code\nSWX3,90071992547409.93,USD\n", buf.String())
+
+	assert.Error(t, WritePrices(&buf, Format("xml"), prices, Options{}))
+}
+
+func TestJSONLinesWriter(t *testing.T) {
+	var buf bytes.Buffer
+	w, err := newRowWriter(&buf, FormatJSONLines, []string{"name\x7f", "地址", "note"}, Options{})
+	if assert.NoError(t, err) {
+		assert.NoError(t, w.write([]any{"a\x00b", "Fort Worth", nil}))
+		assert.NoError(t, w.flush())
+	}
+	line := strings.TrimSuffix(buf.String(), "\n")
+	assert.True(t, json.Valid([]byte(line)), line)
+	var row map[string]any
+	if assert.NoError(t, json.Unmarshal([]byte(line), &row)) {
+		assert.Equal(t, map[string]any{"name\x7f": "a\x00b", "地址": "Fort Worth", "note": nil}, row)
+	}
+}
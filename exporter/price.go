@@ -0,0 +1,78 @@
+package exporter
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+
+	"github.com/hiscaler/swiftx-go/entity"
+)
+
+// PriceColumns 订单价格导出的固定列，之后是按费用描述排序的明细列（列名为 PriceDetailColumnPrefix + 费用描述）
+var PriceColumns = []string{
+	"tracking_number",
+	"amount",
+	"currency_code",
+}
+
+// PriceDetailColumnPrefix 明细列的列名前缀
+const PriceDetailColumnPrefix = "detail_"
+
+// WritePrices 导出订单价格，每个运单一行，每种费用明细一列
+//
+// 同一运单中描述相同的费用明细会合并金额。
+func WritePrices(w io.Writer, format Format, prices []entity.OrderPrice, opts Options) error {
+	descriptions := make([]string, 0)
+	seen := make(map[string]bool)
+	for _, price := range prices {
+		for _, detail := range price.Details {
+			if !seen[detail.Description] {
+				seen[detail.Description] = true
+				descriptions = append(descriptions, detail.Description)
+			}
+		}
+	}
+	sort.Strings(descriptions)
+
+	columns := make([]string, 0, len(PriceColumns)+len(descriptions))
+	columns = append(columns, PriceColumns...)
+	for _, description := range descriptions {
+		columns = append(columns, PriceDetailColumnPrefix+description)
+	}
+	rw, err := newRowWriter(w, format, columns, opts)
+	if err != nil {
+		return err
+	}
+
+	for _, price := range prices {
+		amounts := make(map[string]entity.Money, len(price.Details))
+		for _, detail := range price.Details {
+			amount, ok := amounts[detail.Description]
+			if !ok {
+				amount.Currency = detail.Cost.Currency
+			}
+			if amounts[detail.Description], err = amount.Add(detail.Cost); err != nil {
+				return fmt.Errorf("运单 %s 的费用 %s: %w", price.TrackingNumber, detail.Description, err)
+			}
+		}
+		row := make([]any, 0, len(columns))
+		row = append(row, price.TrackingNumber, decimal(price.Amount), string(price.Amount.Currency))
+		for _, description := range descriptions {
+			if amount, ok := amounts[description]; ok {
+				row = append(row, decimal(amount))
+			} else {
+				row = append(row, nil)
+			}
+		}
+		if err = rw.write(row); err != nil {
+			return err
+		}
+	}
+	return rw.flush()
+}
+
+// decimal 返回固定小数位数的金额，JSON Lines 中输出为数字，避免转换为浮点数丢失精度
+func decimal(m entity.Money) json.Number {
+	return json.Number(m.Decimal())
+}
@@ -0,0 +1,109 @@
+package exporter
+
+import (
+	"io"
+
+	"github.com/hiscaler/swiftx-go/entity"
+)
+
+// TrackingEventColumns 轨迹事件导出列（每个轨迹事件一行）
+var TrackingEventColumns = []string{
+	"tracking_no",
+	"success",
+	"message",
+	"time",
+	"local_time",
+	"local_gmt_offset",
+	"event",
+	"description",
+	"location",
+	"city",
+	"state",
+	"country",
+	"postal_code",
+	"pod_image_count",
+}
+
+// TrackingLatestColumns 最新轨迹导出列（每个运单一行）
+var TrackingLatestColumns = append([]string{"event_count"}, TrackingEventColumns...)
+
+// WriteTrackingEvents 导出物流轨迹，每个轨迹事件一行，没有轨迹的运单输出一行空事件
+func WriteTrackingEvents(w io.Writer, format Format, results []entity.TrackingResult, opts Options) error {
+	rw, err := newRowWriter(w, format, TrackingEventColumns, opts)
+	if err != nil {
+		return err
+	}
+	for _, result := range results {
+		if len(result.TrackingEventList) == 0 {
+			if err = rw.write(trackingRow(result, nil, opts)); err != nil {
+				return err
+			}
+			continue
+		}
+		for i := range result.TrackingEventList {
+			if err = rw.write(trackingRow(result, &result.TrackingEventList[i], opts)); err != nil {
+				return err
+			}
+		}
+	}
+	return rw.flush()
+}
+
+// WriteTrackingLatest 导出物流轨迹，每个运单一行，只包含最新的轨迹事件
+func WriteTrackingLatest(w io.Writer, format Format, results []entity.TrackingResult, opts Options) error {
+	rw, err := newRowWriter(w, format, TrackingLatestColumns, opts)
+	if err != nil {
+		return err
+	}
+	for _, result := range results {
+		row := append([]any{len(result.TrackingEventList)}, trackingRow(result, LatestTrack(result), opts)...)
+		if err = rw.write(row); err != nil {
+			return err
+		}
+	}
+	return rw.flush()
+}
+
+// LatestTrack 返回时间最新的轨迹事件，时间都无法解析时返回第一个事件，没有轨迹时返回 nil
+func LatestTrack(result entity.TrackingResult) *entity.Track {
+	if len(result.TrackingEventList) == 0 {
+		return nil
+	}
+
+	latest := &result.TrackingEventList[0]
+	latestTime, _ := parseLocalTime(latest.LocalTime, latest.LocalGmtOffset)
+	for i := 1; i < len(result.TrackingEventList); i++ {
+		track := &result.TrackingEventList[i]
+		t, ok := parseLocalTime(track.LocalTime, track.LocalGmtOffset)
+		if ok && t.After(latestTime) {
+			latest = track
+			latestTime = t
+		}
+	}
+	return latest
+}
+
+func trackingRow(result entity.TrackingResult, track *entity.Track, opts Options) []any {
+	row := []any{result.TrackingNo, result.Result.Success, result.Result.Message}
+	if track == nil {
+		return append(row, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil)
+	}
+
+	var t any
+	if v, ok := parseLocalTime(track.LocalTime, track.LocalGmtOffset); ok {
+		t = v.In(opts.location()).Format(TimeLayout)
+	}
+	return append(row,
+		t,
+		track.LocalTime,
+		track.LocalGmtOffset,
+		track.Event,
+		track.Description,
+		track.Location,
+		track.CityUppercase,
+		track.Iso3166Sc,
+		track.Iso3166Cc,
+		track.PostalCode,
+		track.PodImageCount,
+	)
+}
@@ -0,0 +1,166 @@
+package exporter
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Format 导出格式
+type Format string
+
+const (
+	FormatCSV       Format = "csv"   // CSV
+	FormatJSONLines Format = "jsonl" // JSON Lines，每行一个 JSON 对象
+)
+
+// TimeLayout 导出的时间格式
+const TimeLayout = "2006-01-02 15:04:05"
+
+// Options 导出选项
+type Options struct {
+	Location *time.Location // 时区，轨迹时间会转换到该时区，默认为 UTC
+	NoHeader bool           // CSV 不输出表头，用于追加写入
+}
+
+func (o Options) location() *time.Location {
+	if o.Location == nil {
+		return time.UTC
+	}
+	return o.Location
+}
+
+// rowWriter 按列顺序写入数据
+type rowWriter interface {
+	write(row []any) error
+	flush() error
+}
+
+func newRowWriter(w io.Writer, format Format, columns []string, opts Options) (rowWriter, error) {
+	switch format {
+	case FormatCSV:
+		cw := csv.NewWriter(w)
+		if !opts.NoHeader {
+			if err := cw.Write(columns); err != nil {
+				return nil, err
+			}
+		}
+		return &csvWriter{w: cw}, nil
+	case FormatJSONLines:
+		keys := make([][]byte, len(columns))
+		for i, column := range columns {
+			key, err := json.Marshal(column)
+			if err != nil {
+				return nil, err
+			}
+			keys[i] = key
+		}
+		return &jsonLinesWriter{w: w, keys: keys}, nil
+	default:
+		return nil, fmt.Errorf("exporter: 无效的导出格式 %s", format)
+	}
+}
+
+type csvWriter struct {
+	w *csv.Writer
+}
+
+func (w *csvWriter) write(row []any) error {
+	record := make([]string, len(row))
+	for i, v := range row {
+		switch val := v.(type) {
+		case nil:
+		case string:
+			record[i] = val
+		case json.Number:
+			record[i] = val.String()
+		case float64:
+			record[i] = strconv.FormatFloat(val, 'f', -1, 64)
+		case int:
+			record[i] = strconv.Itoa(val)
+		case bool:
+			record[i] = strconv.FormatBool(val)
+		default:
+			record[i] = fmt.Sprint(val)
+		}
+	}
+	return w.w.Write(record)
+}
+
+func (w *csvWriter) flush() error {
+	w.w.Flush()
+	return w.w.Error()
+}
+
+// jsonLinesWriter 按列顺序输出 JSON 对象，保证每行的键顺序一致
+type jsonLinesWriter struct {
+	w    io.Writer
+	keys [][]byte // JSON 编码后的列名
+	buf  []byte
+}
+
+func (w *jsonLinesWriter) write(row []any) error {
+	w.buf = append(w.buf[:0], '{')
+	for i, key := range w.keys {
+		if i > 0 {
+			w.buf = append(w.buf, ',')
+		}
+		w.buf = append(w.buf, key...)
+		w.buf = append(w.buf, ':')
+		var v any
+		if i < len(row) {
+			v = row[i]
+		}
+		b, err := json.Marshal(v)
+		if err != nil {
+			return err
+		}
+		w.buf = append(w.buf, b...)
+	}
+	w.buf = append(w.buf, '}', '\n')
+	_, err := w.w.Write(w.buf)
+	return err
+}
+
+func (w *jsonLinesWriter) flush() error {
+	return nil
+}
+
+var gmtOffsetRegexp = regexp.MustCompile(`^(?:GMT|UTC)?\s*([+-])(\d{1,2})(?::?(\d{2}))?$`)
+
+// parseLocalTime 解析轨迹的本地时间和 GMT 偏移，比如 2026-01-13 10:20:30 和 -08:00
+func parseLocalTime(localTime, gmtOffset string) (time.Time, bool) {
+	localTime = strings.TrimSpace(localTime)
+	if localTime == "" {
+		return time.Time{}, false
+	}
+	if t, err := time.Parse(time.RFC3339, localTime); err == nil {
+		return t, true
+	}
+
+	offset := 0
+	if s := strings.TrimSpace(gmtOffset); s != "" {
+		matches := gmtOffsetRegexp.FindStringSubmatch(strings.ToUpper(s))
+		if matches == nil {
+			return time.Time{}, false
+		}
+		hours, _ := strconv.Atoi(matches[2])
+		minutes, _ := strconv.Atoi(matches[3])
+		offset = hours*3600 + minutes*60
+		if matches[1] == "-" {
+			offset = -offset
+		}
+	}
+	zone := time.FixedZone("", offset)
+	for _, layout := range []string{TimeLayout, "2006-01-02T15:04:05", "2006/01/02 15:04:05", "2006-01-02 15:04"} {
+		if t, err := time.ParseInLocation(layout, localTime, zone); err == nil {
+			return t, true
+		}
+	}
+	return time.Time{}, false
+}
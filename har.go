@@ -0,0 +1,297 @@
+package swiftx
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/go-resty/resty/v2"
+)
+
+// HAR HTTP Archive 1.2 文档
+type HAR struct {
+	Log HARLog `json:"log"`
+}
+
+// HARLog HAR 日志
+type HARLog struct {
+	Version string     `json:"version"`
+	Creator HARCreator `json:"creator"`
+	Entries []HAREntry `json:"entries"`
+}
+
+// HARCreator 生成 HAR 的程序
+type HARCreator struct {
+	Name    string `json:"name"`
+	Version string `json:"version"`
+}
+
+// HAREntry 一次请求（每次重试为一条记录）
+type HAREntry struct {
+	StartedDateTime time.Time   `json:"startedDateTime"`
+	Time            float64     `json:"time"` // 总耗时（毫秒）
+	Request         HARRequest  `json:"request"`
+	Response        HARResponse `json:"response"`
+	Cache           struct{}    `json:"cache"`
+	Timings         HARTimings  `json:"timings"`
+	ServerIPAddress string      `json:"serverIPAddress,omitempty"`
+	Comment         string      `json:"comment,omitempty"` // 请求失败时的错误信息
+}
+
+// HARNameValue 名称和值
+type HARNameValue struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+// HARRequest HAR 请求
+type HARRequest struct {
+	Method      string         `json:"method"`
+	URL         string         `json:"url"`
+	HTTPVersion string         `json:"httpVersion"`
+	Cookies     []HARNameValue `json:"cookies"`
+	Headers     []HARNameValue `json:"headers"`
+	QueryString []HARNameValue `json:"queryString"`
+	PostData    *HARPostData   `json:"postData,omitempty"`
+	HeadersSize int            `json:"headersSize"`
+	BodySize    int            `json:"bodySize"`
+}
+
+// HARPostData HAR 请求内容
+type HARPostData struct {
+	MimeType string `json:"mimeType"`
+	Text     string `json:"text"`
+}
+
+// HARResponse HAR 响应
+type HARResponse struct {
+	Status      int            `json:"status"`
+	StatusText  string         `json:"statusText"`
+	HTTPVersion string         `json:"httpVersion"`
+	Cookies     []HARNameValue `json:"cookies"`
+	Headers     []HARNameValue `json:"headers"`
+	Content     HARContent     `json:"content"`
+	RedirectURL string         `json:"redirectURL"`
+	HeadersSize int            `json:"headersSize"`
+	BodySize    int            `json:"bodySize"`
+}
+
+// HARContent HAR 响应内容
+type HARContent struct {
+	Size     int    `json:"size"`
+	MimeType string `json:"mimeType"`
+	Text     string `json:"text,omitempty"`
+}
+
+// HARTimings HAR 耗时（毫秒，-1 表示不适用）
+type HARTimings struct {
+	Blocked float64 `json:"blocked"`
+	DNS     float64 `json:"dns"`
+	Connect float64 `json:"connect"`
+	SSL     float64 `json:"ssl"`
+	Send    float64 `json:"send"`
+	Wait    float64 `json:"wait"`
+	Receive float64 `json:"receive"`
+}
+
+// HARRecorderOptions HAR 记录配置
+type HARRecorderOptions struct {
+	MaxEntries int                      // 最多保留的记录数，超出时丢弃最早的记录，默认为 100
+	OnError    func(har HAR, err error) // API 调用失败时调用，可用于将 HAR 写入文件后提交给 SwiftX 客服
+}
+
+// HARRecorder 将 Client 的请求记录为 HAR，请求头和请求、响应内容使用 Client 的脱敏配置（WithRedaction）处理
+type HARRecorder struct {
+	opts    HARRecorderOptions
+	mu      sync.Mutex
+	entries []HAREntry
+}
+
+// NewHARRecorder 创建 HAR 记录器
+func NewHARRecorder(opts HARRecorderOptions) *HARRecorder {
+	if opts.MaxEntries <= 0 {
+		opts.MaxEntries = 100
+	}
+	return &HARRecorder{opts: opts}
+}
+
+// WithHARRecorder 使用 HAR 记录器记录 Client 的请求，同时启用 resty 的请求耗时跟踪
+func WithHARRecorder(recorder *HARRecorder) Option {
+	return func(c *Client) {
+		c.harRecorder = recorder
+	}
+}
+
+// HAR 返回当前记录的 HAR 文档
+func (r *HARRecorder) HAR() HAR {
+	r.mu.Lock()
+	entries := make([]HAREntry, len(r.entries))
+	copy(entries, r.entries)
+	r.mu.Unlock()
+	return HAR{Log: HARLog{
+		Version: "1.2",
+		Creator: HARCreator{Name: "swiftx-go", Version: Version},
+		Entries: entries,
+	}}
+}
+
+// WriteTo 将当前记录的 HAR 文档以 JSON 格式写入 w
+func (r *HARRecorder) WriteTo(w io.Writer) (int64, error) {
+	b, err := json.MarshalIndent(r.HAR(), "", "  ")
+	if err != nil {
+		return 0, err
+	}
+	n, err := w.Write(b)
+	return int64(n), err
+}
+
+// Reset 清空记录
+func (r *HARRecorder) Reset() {
+	r.mu.Lock()
+	r.entries = nil
+	r.mu.Unlock()
+}
+
+func (r *HARRecorder) add(entry HAREntry) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if len(r.entries) >= r.opts.MaxEntries {
+		r.entries = append(r.entries[:0], r.entries[len(r.entries)-r.opts.MaxEntries+1:]...)
+	}
+	r.entries = append(r.entries, entry)
+}
+
+// attach 注册 resty 钩子，记录每次请求（包括重试）
+func (r *HARRecorder) attach(httpClient *resty.Client, redaction Redaction) {
+	httpClient.EnableTrace()
+	httpClient.
+		OnAfterResponse(func(_ *resty.Client, resp *resty.Response) error {
+			r.add(harEntry(resp.Request, resp, redaction, nil))
+			return nil
+		}).
+		OnError(func(req *resty.Request, err error) {
+			var respErr *resty.ResponseError
+			if errors.As(err, &respErr) && respErr.Response != nil && respErr.Response.RawResponse != nil {
+				// 已在 OnAfterResponse 中记录
+				return
+			}
+			if req.RawRequest == nil {
+				return
+			}
+			r.add(harEntry(req, nil, redaction, err))
+		})
+}
+
+// middleware API 调用失败时调用 OnError
+func (r *HARRecorder) middleware() Middleware {
+	return func(next Handler) Handler {
+		return HandlerFunc(func(ctx context.Context, call *Call) error {
+			err := next.Handle(ctx, call)
+			if err != nil && r.opts.OnError != nil {
+				r.opts.OnError(r.HAR(), err)
+			}
+			return err
+		})
+	}
+}
+
+func harEntry(req *resty.Request, resp *resty.Response, redaction Redaction, err error) HAREntry {
+	rawRequest := req.RawRequest
+	if resp != nil && resp.RawResponse != nil && resp.RawResponse.Request != nil {
+		// 签名后实际发送的请求
+		rawRequest = resp.RawResponse.Request
+	} else if signed := signedRequest(req.Context()); signed != nil {
+		// 请求失败没有响应时，使用最近一次签名后的请求
+		rawRequest = signed
+	}
+	trace := req.TraceInfo()
+	entry := HAREntry{
+		StartedDateTime: req.Time,
+		Time:            milliseconds(trace.TotalTime),
+		Request: HARRequest{
+			Method:      rawRequest.Method,
+			URL:         rawRequest.URL.String(),
+			HTTPVersion: "HTTP/1.1",
+			Cookies:     []HARNameValue{},
+			Headers:     harHeaders(redaction.Header(rawRequest.Header)),
+			QueryString: []HARNameValue{},
+			HeadersSize: -1,
+			BodySize:    -1,
+		},
+		Response: HARResponse{
+			Cookies:     []HARNameValue{},
+			Headers:     []HARNameValue{},
+			HeadersSize: -1,
+			BodySize:    -1,
+		},
+		Timings: HARTimings{
+			Blocked: -1,
+			DNS:     milliseconds(trace.DNSLookup),
+			Connect: milliseconds(trace.TCPConnTime),
+			SSL:     milliseconds(trace.TLSHandshake),
+			Send:    0,
+			Wait:    milliseconds(trace.ServerTime),
+			Receive: milliseconds(trace.ResponseTime),
+		},
+	}
+	if trace.RemoteAddr != nil {
+		entry.ServerIPAddress = trace.RemoteAddr.String()
+	}
+	for name, values := range rawRequest.URL.Query() {
+		for _, value := range values {
+			entry.Request.QueryString = append(entry.Request.QueryString, HARNameValue{Name: name, Value: value})
+		}
+	}
+	sortHARNameValues(entry.Request.QueryString)
+	if body, readErr := readBody(rawRequest); readErr == nil && len(body) > 0 {
+		entry.Request.BodySize = len(body)
+		entry.Request.PostData = &HARPostData{
+			MimeType: rawRequest.Header.Get("Content-Type"),
+			Text:     string(redaction.JSON(body)),
+		}
+	}
+
+	if resp != nil && resp.RawResponse != nil {
+		body := resp.Body()
+		entry.Response.Status = resp.StatusCode()
+		entry.Response.StatusText = http.StatusText(resp.StatusCode())
+		entry.Response.HTTPVersion = resp.Proto()
+		entry.Response.Headers = harHeaders(resp.Header())
+		entry.Response.BodySize = len(body)
+		entry.Response.Content = HARContent{
+			Size:     len(body),
+			MimeType: resp.Header().Get("Content-Type"),
+			Text:     string(redaction.JSON(body)),
+		}
+	}
+	if err != nil {
+		entry.Comment = err.Error()
+	}
+	return entry
+}
+
+func harHeaders(header http.Header) []HARNameValue {
+	values := make([]HARNameValue, 0, len(header))
+	for name, vs := range header {
+		for _, v := range vs {
+			values = append(values, HARNameValue{Name: name, Value: v})
+		}
+	}
+	sortHARNameValues(values)
+	return values
+}
+
+func sortHARNameValues(values []HARNameValue) {
+	sort.SliceStable(values, func(i, j int) bool {
+		return values[i].Name < values[j].Name
+	})
+}
+
+func milliseconds(d time.Duration) float64 {
+	return float64(d) / float64(time.Millisecond)
+}
@@ -0,0 +1,109 @@
+package swiftx
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHARRecorder(t *testing.T) {
+	var requests atomic.Int32
+	var failed *HAR
+	recorder := NewHARRecorder(HARRecorderOptions{
+		MaxEntries: 2,
+		OnError: func(har HAR, err error) {
+			failed = &har
+		},
+	})
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if requests.Add(1) == 1 {
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		if strings.HasSuffix(r.URL.Path, EndpointCancelOrder) {
+			fmt.Fprint(w, `{"success":false,"message":"订单已揽收"}`)
+			return
+		}
+		fmt.Fprint(w, r.URL.Query().Get("i"))
+	}, WithHARRecorder(recorder))
+
+	_, err := c.Services.Ping.Pong(ctx, 5)
+	assert.NoError(t, err)
+	har := recorder.HAR()
+	assert.Equal(t, "1.2", har.Log.Version)
+	if assert.Len(t, har.Log.Entries, 2) {
+		// 重试的每次请求都会记录
+		assert.Equal(t, http.StatusTooManyRequests, har.Log.Entries[0].Response.Status)
+		entry := har.Log.Entries[1]
+		assert.Equal(t, http.StatusOK, entry.Response.Status)
+		assert.Equal(t, http.MethodGet, entry.Request.Method)
+		assert.Contains(t, entry.Request.QueryString, HARNameValue{Name: "i", Value: "5"})
+		assert.Contains(t, entry.Request.Headers, HARNameValue{Name: HeaderSignature, Value: Redacted})
+		assert.Contains(t, entry.Request.Headers, HARNameValue{Name: HeaderAppKey, Value: "test-app-key"})
+		assert.Equal(t, "5", entry.Response.Content.Text)
+		assert.Greater(t, entry.Time, float64(0))
+		assert.False(t, entry.StartedDateTime.IsZero())
+	}
+	assert.Nil(t, failed)
+
+	_, err = c.Services.Order.Cancel(ctx, "SWX1")
+	assert.Error(t, err)
+	if assert.NotNil(t, failed) && assert.Len(t, failed.Log.Entries, 2) {
+		entry := failed.Log.Entries[1]
+		if assert.NotNil(t, entry.Request.PostData) {
+			assert.JSONEq(t, `{"trackingNo":"SWX1"}`, entry.Request.PostData.Text)
+		}
+	}
+
+	var buf bytes.Buffer
+	_, err = recorder.WriteTo(&buf)
+	assert.NoError(t, err)
+	var decoded HAR
+	assert.NoError(t, json.Unmarshal(buf.Bytes(), &decoded))
+	assert.Len(t, decoded.Log.Entries, 2)
+
+	recorder.Reset()
+	assert.Empty(t, recorder.HAR().Log.Entries)
+}
+
+func TestHARRecorder_TransportError(t *testing.T) {
+	var failed *HAR
+	recorder := NewHARRecorder(HARRecorderOptions{
+		OnError: func(har HAR, err error) {
+			failed = &har
+		},
+	})
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {},
+		WithHARRecorder(recorder),
+		WithTransport(errTransport{err: errors.New("connection refused")}),
+	)
+	_, call, err := c.Services.Ping.pong(ctx, 1)
+	assert.Error(t, err)
+	// 请求失败没有响应时，也记录签名后的请求头
+	assert.NotEmpty(t, call.SignedHeader.Get(HeaderSignature))
+	if assert.NotNil(t, failed) && assert.NotEmpty(t, failed.Log.Entries) {
+		entry := failed.Log.Entries[len(failed.Log.Entries)-1]
+		assert.Equal(t, 0, entry.Response.Status)
+		assert.Contains(t, entry.Comment, "connection refused")
+		assert.Contains(t, entry.Request.Headers, HARNameValue{Name: HeaderSignature, Value: Redacted})
+		for _, name := range []string{HeaderTimestamp, HeaderNonce, HeaderAppKey} {
+			assert.Contains(t, harHeaderNames(entry.Request.Headers), name)
+		}
+	}
+}
+
+func harHeaderNames(headers []HARNameValue) []string {
+	names := make([]string, len(headers))
+	for i, header := range headers {
+		names[i] = header.Name
+	}
+	return names
+}
@@ -0,0 +1,199 @@
+package swiftx
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/rand/v2"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// HealthCheckerOptions 健康检查配置
+type HealthCheckerOptions struct {
+	Interval               time.Duration     // 检查间隔，默认为 30 秒
+	Timeout                time.Duration     // 每次检查的超时时间，默认为 5 秒
+	Window                 int               // 计算可用率的检查次数，默认为 20
+	MinAvailability        float64           // 可用率不低于该值时视为可用，为 0 时使用默认值 0.5，小于 0 时不检查可用率
+	MaxConsecutiveFailures int               // 连续失败达到该次数时视为不可用，默认为 3
+	OnCheck                func(HealthCheck) // 每次检查后调用
+}
+
+// HealthCheck 一次健康检查的结果
+type HealthCheck struct {
+	Time           time.Time     // 检查时间
+	Latency        time.Duration // 耗时
+	OK             bool          // 是否成功（请求成功且返回值与请求值一致）
+	SignatureValid bool          // 签名是否有效（收到响应且状态码不是 401）
+	Err            error         // 失败原因
+}
+
+// HealthStatus 健康状态
+type HealthStatus struct {
+	Ready               bool          // 是否可用
+	Checks              int           // 窗口内的检查次数
+	Availability        float64       // 窗口内的可用率
+	AverageLatency      time.Duration // 窗口内成功检查的平均耗时
+	ConsecutiveFailures int           // 连续失败次数
+	Last                HealthCheck   // 最近一次检查
+}
+
+// HealthChecker 通过 pingPong 接口定期检查 SwiftX API 的可用性
+//
+// 每次使用随机数请求并校验返回值，记录耗时和签名是否有效，按最近 Window 次检查计算可用率。
+// HealthChecker 实现了 http.Handler，可用作 Kubernetes 就绪探针：可用时返回 200，否则返回 503。
+type HealthChecker struct {
+	ping pingService
+	opts HealthCheckerOptions
+
+	mu                  sync.RWMutex
+	checks              []HealthCheck // 环形缓冲区
+	next                int
+	consecutiveFailures int
+}
+
+// NewHealthChecker 创建健康检查
+func NewHealthChecker(c *Client, opts HealthCheckerOptions) *HealthChecker {
+	if opts.Interval <= 0 {
+		opts.Interval = 30 * time.Second
+	}
+	if opts.Timeout <= 0 {
+		opts.Timeout = 5 * time.Second
+	}
+	if opts.Window <= 0 {
+		opts.Window = 20
+	}
+	// 0 无法与未设置区分，使用默认值；只按连续失败次数判断时设置为负数
+	if opts.MinAvailability == 0 {
+		opts.MinAvailability = 0.5
+	}
+	if opts.MaxConsecutiveFailures <= 0 {
+		opts.MaxConsecutiveFailures = 3
+	}
+	return &HealthChecker{
+		ping:   c.Services.Ping,
+		opts:   opts,
+		checks: make([]HealthCheck, 0, opts.Window),
+	}
+}
+
+// Run 立即检查一次，之后按 Interval 定期检查，直到 ctx 结束
+func (h *HealthChecker) Run(ctx context.Context) {
+	ticker := time.NewTicker(h.opts.Interval)
+	defer ticker.Stop()
+	for {
+		h.Check(ctx)
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Check 检查一次并记录结果
+func (h *HealthChecker) Check(ctx context.Context) HealthCheck {
+	ctx, cancel := context.WithTimeout(ctx, h.opts.Timeout)
+	defer cancel()
+
+	n := rand.IntN(1 << 30)
+	check := HealthCheck{Time: time.Now()}
+	echo, call, err := h.ping.pong(ctx, n)
+	check.Latency = time.Since(check.Time)
+	check.SignatureValid = call.StatusCode != 0 && call.StatusCode != http.StatusUnauthorized
+	switch {
+	case err != nil:
+		check.Err = err
+	case echo != n:
+		check.Err = fmt.Errorf("pingPong 返回值 %d 与请求值 %d 不一致", echo, n)
+	default:
+		check.OK = true
+	}
+
+	h.mu.Lock()
+	if len(h.checks) < h.opts.Window {
+		h.checks = append(h.checks, check)
+	} else {
+		h.checks[h.next] = check
+	}
+	h.next = (h.next + 1) % h.opts.Window
+	if check.OK {
+		h.consecutiveFailures = 0
+	} else {
+		h.consecutiveFailures++
+	}
+	h.mu.Unlock()
+
+	if h.opts.OnCheck != nil {
+		h.opts.OnCheck(check)
+	}
+	return check
+}
+
+// Status 返回当前健康状态，还没有检查时不可用
+func (h *HealthChecker) Status() HealthStatus {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	status := HealthStatus{
+		Checks:              len(h.checks),
+		ConsecutiveFailures: h.consecutiveFailures,
+	}
+	if status.Checks == 0 {
+		return status
+	}
+	status.Last = h.checks[(h.next-1+len(h.checks))%len(h.checks)]
+	ok := 0
+	var latency time.Duration
+	for _, check := range h.checks {
+		if check.OK {
+			ok++
+			latency += check.Latency
+		}
+	}
+	status.Availability = float64(ok) / float64(status.Checks)
+	if ok > 0 {
+		status.AverageLatency = latency / time.Duration(ok)
+	}
+	status.Ready = status.Availability >= h.opts.MinAvailability && status.ConsecutiveFailures < h.opts.MaxConsecutiveFailures
+	return status
+}
+
+// ServeHTTP 返回 JSON 格式的健康状态，可用时状态码为 200，否则为 503
+func (h *HealthChecker) ServeHTTP(w http.ResponseWriter, _ *http.Request) {
+	status := h.Status()
+	body := struct {
+		Status              string  `json:"status"`
+		Checks              int     `json:"checks"`
+		Availability        float64 `json:"availability"`
+		AverageLatencyMs    float64 `json:"average_latency_ms"`
+		ConsecutiveFailures int     `json:"consecutive_failures"`
+		SignatureValid      bool    `json:"signature_valid"`
+		LastCheckAt         string  `json:"last_check_at,omitempty"`
+		LastError           string  `json:"last_error,omitempty"`
+	}{
+		Status:              "ok",
+		Checks:              status.Checks,
+		Availability:        status.Availability,
+		AverageLatencyMs:    milliseconds(status.AverageLatency),
+		ConsecutiveFailures: status.ConsecutiveFailures,
+		SignatureValid:      status.Last.SignatureValid,
+	}
+	if !status.Last.Time.IsZero() {
+		body.LastCheckAt = status.Last.Time.Format(time.RFC3339)
+	}
+	if status.Last.Err != nil {
+		body.LastError = status.Last.Err.Error()
+	}
+
+	code := http.StatusOK
+	if !status.Ready {
+		code = http.StatusServiceUnavailable
+		body.Status = "unavailable"
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(code)
+	_ = json.NewEncoder(w).Encode(body)
+}
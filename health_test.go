@@ -0,0 +1,108 @@
+package swiftx
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHealthChecker(t *testing.T) {
+	var mode atomic.Value // ok、wrong、unauthorized
+	mode.Store("ok")
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch mode.Load() {
+		case "unauthorized":
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		case "wrong":
+			w.Header().Set("Content-Type", "application/json")
+			fmt.Fprint(w, "-1")
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, r.URL.Query().Get("i"))
+	})
+	checker := NewHealthChecker(c, HealthCheckerOptions{Window: 4, MinAvailability: 0.5, MaxConsecutiveFailures: 2})
+
+	probe := func() (int, map[string]any) {
+		rec := httptest.NewRecorder()
+		checker.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/readyz", nil))
+		var body map[string]any
+		assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+		return rec.Code, body
+	}
+
+	// 还没有检查
+	code, body := probe()
+	assert.Equal(t, http.StatusServiceUnavailable, code)
+	assert.Equal(t, "unavailable", body["status"])
+
+	check := checker.Check(ctx)
+	assert.True(t, check.OK)
+	assert.True(t, check.SignatureValid)
+	code, body = probe()
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, float64(1), body["availability"])
+
+	mode.Store("wrong")
+	check = checker.Check(ctx)
+	assert.False(t, check.OK)
+	assert.True(t, check.SignatureValid)
+	assert.Error(t, check.Err)
+	status := checker.Status()
+	assert.True(t, status.Ready)
+	assert.Equal(t, 0.5, status.Availability)
+
+	mode.Store("unauthorized")
+	check = checker.Check(ctx)
+	assert.False(t, check.SignatureValid)
+	code, body = probe()
+	assert.Equal(t, http.StatusServiceUnavailable, code)
+	assert.Equal(t, float64(2), body["consecutive_failures"])
+	assert.Equal(t, false, body["signature_valid"])
+
+	// 窗口只保留最近 4 次检查
+	mode.Store("ok")
+	for i := 0; i < 3; i++ {
+		checker.Check(ctx)
+	}
+	status = checker.Status()
+	assert.Equal(t, 4, status.Checks)
+	assert.Equal(t, 0.75, status.Availability)
+	assert.True(t, status.Ready)
+	assert.True(t, status.Last.OK)
+	assert.Greater(t, int64(status.AverageLatency), int64(0))
+}
+
+func TestNewHealthChecker_MinAvailability(t *testing.T) {
+	var ok atomic.Bool
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if ok.Load() {
+			fmt.Fprint(w, r.URL.Query().Get("i"))
+		} else {
+			fmt.Fprint(w, "-1")
+		}
+	})
+	assert.Equal(t, 0.5, NewHealthChecker(c, HealthCheckerOptions{}).opts.MinAvailability)
+
+	// 负数表示不检查可用率，只按连续失败次数判断
+	checker := NewHealthChecker(c, HealthCheckerOptions{Window: 4, MinAvailability: -1, MaxConsecutiveFailures: 3})
+	for i := 0; i < 2; i++ {
+		checker.Check(ctx)
+	}
+	status := checker.Status()
+	assert.Equal(t, float64(0), status.Availability)
+	assert.True(t, status.Ready)
+	checker.Check(ctx)
+	assert.False(t, checker.Status().Ready)
+
+	ok.Store(true)
+	checker.Check(ctx)
+	assert.True(t, checker.Status().Ready)
+}
@@ -0,0 +1,463 @@
+package importer
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/hiscaler/swiftx-go"
+	"github.com/hiscaler/swiftx-go/entity"
+)
+
+// 订单字段，同一订单号的多行数据合并为一个订单，每行对应 SkuList 中的一个 SKU
+const (
+	FieldOrderNumber       = "order_number"       // 上游订单号（必须），用于合并多行 SKU
+	FieldOrderScope        = "order_scope"        // 订单类型
+	FieldServiceType       = "service_type"       // 服务类型
+	FieldDeliveryMethod    = "delivery_method"    // 送货方式
+	FieldCooperationMethod = "cooperation_method" // 合作方式
+	FieldSelfPickupCode    = "self_pickup_code"   // 自提码
+	FieldCustomerNote      = "customer_note"      // 客户备注
+	FieldExtSortingCode    = "ext_sorting_code"   // 外部分拣码
+
+	FieldSenderName          = "sender_name"           // 发件人姓名
+	FieldSenderPhoneNumber   = "sender_phone_number"   // 发件人电话号码
+	FieldSenderRegionCode    = "sender_region_code"    // 发件人国家编码
+	FieldSenderStateProvince = "sender_state_province" // 发件人省/州
+	FieldSenderCity          = "sender_city"           // 发件人城市
+	FieldSenderDistrict      = "sender_district"       // 发件人区域/县
+	FieldSenderStreetAddress = "sender_street_address" // 发件人街道地址
+	FieldSenderBuilding      = "sender_building"       // 发件人建筑物名称
+	FieldSenderPostalCode    = "sender_postal_code"    // 发件人邮编
+
+	FieldRecipientName          = "recipient_name"           // 收件人姓名
+	FieldRecipientPhoneNumber   = "recipient_phone_number"   // 收件人电话号码
+	FieldRecipientRegionCode    = "recipient_region_code"    // 收件人国家编码
+	FieldRecipientStateProvince = "recipient_state_province" // 收件人省/州
+	FieldRecipientCity          = "recipient_city"           // 收件人城市
+	FieldRecipientDistrict      = "recipient_district"       // 收件人区域/县
+	FieldRecipientStreetAddress = "recipient_street_address" // 收件人街道地址
+	FieldRecipientBuilding      = "recipient_building"       // 收件人建筑物名称
+	FieldRecipientPostalCode    = "recipient_postal_code"    // 收件人邮编
+
+	FieldUseImperialUnit = "use_imperial_unit" // 是否使用英制单位
+	FieldWeight          = "weight"            // 重量
+	FieldLength          = "length"            // 长度
+	FieldWidth           = "width"             // 宽度
+	FieldHeight          = "height"            // 高度
+	FieldValueAmount     = "value_amount"      // 包裹总价值
+	FieldValueCurrency   = "value_currency"    // 包裹总价值币种
+	FieldCustomerName    = "customer_name"     // 客户名
+	FieldStoreName       = "store_name"        // 店铺名
+
+	FieldSkuName     = "sku_name"     // SKU 名称
+	FieldSkuQuantity = "sku_quantity" // SKU 数量
+	FieldSkuCode     = "sku_code"     // SKU 商品编码
+	FieldSkuValue    = "sku_value"    // SKU 单价
+	FieldSkuCurrency = "sku_currency" // SKU 币种
+)
+
+// Mapping CSV 表头映射，键为字段（Field* 常量），值为 CSV 中的表头名称（不区分大小写）
+type Mapping map[string]string
+
+// DefaultMapping 默认映射，表头名称与字段名称相同
+func DefaultMapping() Mapping {
+	m := make(Mapping, len(orderSetters)+len(skuSetters))
+	for field := range orderSetters {
+		m[field] = field
+	}
+	for field := range skuSetters {
+		m[field] = field
+	}
+	return m
+}
+
+// RowError 导入错误
+type RowError struct {
+	Rows        []int  // CSV 行号（表头为第 1 行）
+	OrderNumber string // 订单号
+	Err         error  // 错误信息
+}
+
+func (e RowError) Error() string {
+	rows := make([]string, len(e.Rows))
+	for i, row := range e.Rows {
+		rows[i] = strconv.Itoa(row)
+	}
+	if e.OrderNumber == "" {
+		return fmt.Sprintf("第 %s 行: %s", strings.Join(rows, ", "), e.Err)
+	}
+	return fmt.Sprintf("第 %s 行（订单号 %s）: %s", strings.Join(rows, ", "), e.OrderNumber, e.Err)
+}
+
+func (e RowError) Unwrap() error {
+	return e.Err
+}
+
+// Result 导入结果
+type Result struct {
+	Requests []swiftx.CreateOrderRequest // 通过验证的订单，可直接用于批量创建
+	Errors   []RowError                  // 错误报告，按行号排序
+}
+
+// CSVImporter CSV 订单导入
+type CSVImporter struct {
+	Mapping  Mapping                   // 表头映射
+	Template swiftx.CreateOrderRequest // 订单模板，CSV 中未提供的字段使用模板中的值，比如固定的发货地址
+	Comma    rune                      // 分隔符，默认为逗号
+	Limits   swiftx.ServiceLimits      // 包裹重量和尺寸限制，与创建订单的 Client 的 WithServiceLimits 一致，为空时不检查
+}
+
+// NewCSVImporter 创建 CSV 导入器，mapping 为空时使用 DefaultMapping
+func NewCSVImporter(mapping Mapping) *CSVImporter {
+	if len(mapping) == 0 {
+		mapping = DefaultMapping()
+	}
+	return &CSVImporter{Mapping: mapping}
+}
+
+// group 同一订单号的数据
+type group struct {
+	rows    []int
+	request swiftx.CreateOrderRequest
+	values  map[string]string // 订单级字段的原始值，用于检查多行数据是否一致
+	err     error
+}
+
+// Import 读取 CSV 数据并转换为订单请求
+//
+// 只有 CSV 格式错误或缺少必需的表头时返回 error，数据错误记录在 Result.Errors 中。
+func (im *CSVImporter) Import(r io.Reader) (Result, error) {
+	var result Result
+	reader := csv.NewReader(r)
+	if im.Comma != 0 {
+		reader.Comma = im.Comma
+	}
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err != nil {
+		if errors.Is(err, io.EOF) {
+			return result, errors.New("CSV 数据为空")
+		}
+		return result, err
+	}
+	columns, err := im.columns(header)
+	if err != nil {
+		return result, err
+	}
+	// 按表头的列顺序处理字段，保证同一行有多个错误时报告的错误是确定的
+	fields := make([]string, 0, len(columns))
+	for field := range columns {
+		fields = append(fields, field)
+	}
+	sort.Slice(fields, func(i, j int) bool {
+		if columns[fields[i]] != columns[fields[j]] {
+			return columns[fields[i]] < columns[fields[j]]
+		}
+		return fields[i] < fields[j]
+	})
+
+	groups := make(map[string]*group)
+	orderNumbers := make([]string, 0)
+	for line := 2; ; line++ {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return result, err
+		}
+
+		values := make(map[string]string, len(columns))
+		empty := true
+		for field, index := range columns {
+			if index < len(record) {
+				if v := strings.TrimSpace(record[index]); v != "" {
+					values[field] = v
+					empty = false
+				}
+			}
+		}
+		if empty {
+			continue
+		}
+
+		orderNumber := values[FieldOrderNumber]
+		if orderNumber == "" {
+			result.Errors = append(result.Errors, RowError{Rows: []int{line}, Err: errors.New("订单号不能为空")})
+			continue
+		}
+		g, ok := groups[orderNumber]
+		if !ok {
+			g = &group{request: im.Template, values: make(map[string]string)}
+			g.request.PackageInfo.SkuList = nil
+			groups[orderNumber] = g
+			orderNumbers = append(orderNumbers, orderNumber)
+		}
+		g.rows = append(g.rows, line)
+		if g.err != nil {
+			continue
+		}
+		g.err = g.apply(fields, values, line)
+	}
+
+	for _, orderNumber := range orderNumbers {
+		g := groups[orderNumber]
+		err := g.err
+		if err == nil {
+			err = g.request.ValidateWithLimits(im.Limits)
+		}
+		if err != nil {
+			result.Errors = append(result.Errors, RowError{Rows: g.rows, OrderNumber: orderNumber, Err: err})
+			continue
+		}
+		result.Requests = append(result.Requests, g.request)
+	}
+	sort.SliceStable(result.Errors, func(i, j int) bool {
+		return result.Errors[i].Rows[0] < result.Errors[j].Rows[0]
+	})
+	return result, nil
+}
+
+// columns 根据映射查找字段所在的列
+func (im *CSVImporter) columns(header []string) (map[string]int, error) {
+	indexes := make(map[string]int, len(header))
+	for i, name := range header {
+		if i == 0 {
+			name = strings.TrimPrefix(name, "\ufeff") // Excel 导出的 UTF-8 BOM
+		}
+		indexes[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+
+	columns := make(map[string]int, len(im.Mapping))
+	for field, name := range im.Mapping {
+		_, isOrderField := orderSetters[field]
+		_, isSkuField := skuSetters[field]
+		if !isOrderField && !isSkuField {
+			return nil, fmt.Errorf("无效的字段 %s", field)
+		}
+		if i, ok := indexes[strings.ToLower(strings.TrimSpace(name))]; ok {
+			columns[field] = i
+		}
+	}
+	if _, ok := columns[FieldOrderNumber]; !ok {
+		return nil, fmt.Errorf("缺少订单号列 %s", im.Mapping[FieldOrderNumber])
+	}
+	return columns, nil
+}
+
+// apply 按 fields 的顺序将一行数据写入订单
+func (g *group) apply(fields []string, values map[string]string, line int) error {
+	for _, field := range fields {
+		setter, isOrderField := orderSetters[field]
+		v, ok := values[field]
+		if !isOrderField || !ok {
+			continue
+		}
+		if prev, exists := g.values[field]; exists {
+			if prev != v {
+				return fmt.Errorf("第 %d 行的 %s 值 %s 与同一订单的其他行（%s）不一致", line, field, v, prev)
+			}
+			continue
+		}
+		if err := setter(&g.request, v); err != nil {
+			return fmt.Errorf("第 %d 行的 %s: %w", line, field, err)
+		}
+		g.values[field] = v
+	}
+
+	var sku swiftx.CreateOrderPackageGoods
+	hasSku := false
+	for _, field := range fields {
+		setter, isSkuField := skuSetters[field]
+		v, ok := values[field]
+		if !isSkuField || !ok {
+			continue
+		}
+		if err := setter(&sku, v); err != nil {
+			return fmt.Errorf("第 %d 行的 %s: %w", line, field, err)
+		}
+		hasSku = true
+	}
+	if sku.Value.Currency != "" && !sku.Value.Valid {
+		return fmt.Errorf("第 %d 行的 %s: 缺少 %s", line, FieldSkuCurrency, FieldSkuValue)
+	}
+	if hasSku {
+		g.request.PackageInfo.SkuList = append(g.request.PackageInfo.SkuList, sku)
+	}
+	return nil
+}
+
+// numberPattern 数值列和金额列共用的数值格式：整数部分可以使用千位分隔符（比如 1,234.5），不支持指数、分数、NaN 和 Inf
+var numberPattern = regexp.MustCompile(`^[+-]?(\d+|\d{1,3}(,\d{3})+)?(\.\d+)?$`)
+
+// normalizeNumber 校验数值格式并去掉千位分隔符，千位分隔符只能出现在整数部分每 3 位的位置，比如 1,5 是无效的数值
+func normalizeNumber(v string) (string, error) {
+	if !numberPattern.MatchString(v) || strings.Trim(v, "+-.") == "" {
+		return "", fmt.Errorf("无效的数值 %s", v)
+	}
+	return strings.ReplaceAll(v, ",", ""), nil
+}
+
+// parseFloat 解析数值，只接受有限值
+func parseFloat(v string) (float64, error) {
+	n, err := normalizeNumber(v)
+	if err != nil {
+		return 0, err
+	}
+	f, err := strconv.ParseFloat(n, 64)
+	if err != nil || math.IsNaN(f) || math.IsInf(f, 0) {
+		return 0, fmt.Errorf("无效的数值 %s", v)
+	}
+	return f, nil
+}
+
+// parseMoney 按数值列相同的格式解析金额
+func parseMoney(v string, currency entity.Currency) (entity.Money, error) {
+	n, err := normalizeNumber(v)
+	if err != nil {
+		return entity.Money{}, fmt.Errorf("无效的金额 %s", v)
+	}
+	return entity.ParseMoney(n, currency)
+}
+
+func parseBool(v string) (bool, error) {
+	switch strings.ToLower(v) {
+	case "1", "true", "yes", "y", "是":
+		return true, nil
+	case "0", "false", "no", "n", "否":
+		return false, nil
+	}
+	return false, fmt.Errorf("无效的布尔值 %s", v)
+}
+
+type orderSetter func(req *swiftx.CreateOrderRequest, v string) error
+
+func stringSetter(fn func(req *swiftx.CreateOrderRequest) *string) orderSetter {
+	return func(req *swiftx.CreateOrderRequest, v string) error {
+		*fn(req) = v
+		return nil
+	}
+}
+
+func enumSetter[T ~string](parse func(string) (T, error), fn func(req *swiftx.CreateOrderRequest) *T) orderSetter {
+	return func(req *swiftx.CreateOrderRequest, v string) error {
+		value, err := parse(v)
+		if err != nil {
+			return err
+		}
+		*fn(req) = value
+		return nil
+	}
+}
+
+func floatSetter(fn func(req *swiftx.CreateOrderRequest) *float64) orderSetter {
+	return func(req *swiftx.CreateOrderRequest, v string) error {
+		f, err := parseFloat(v)
+		if err != nil {
+			return err
+		}
+		*fn(req) = f
+		return nil
+	}
+}
+
+var orderSetters = map[string]orderSetter{
+	FieldOrderNumber:       stringSetter(func(r *swiftx.CreateOrderRequest) *string { return &r.ShippingLabelInfo.OrderNumber }),
+	FieldOrderScope:        enumSetter(entity.ParseOrderScope, func(r *swiftx.CreateOrderRequest) *entity.OrderScope { return &r.OrderScope }),
+	FieldServiceType:       enumSetter(entity.ParseServiceType, func(r *swiftx.CreateOrderRequest) *entity.ServiceType { return &r.ServiceType }),
+	FieldDeliveryMethod:    enumSetter(entity.ParseDeliveryMethod, func(r *swiftx.CreateOrderRequest) *entity.DeliveryMethod { return &r.DeliveryMethod }),
+	FieldCooperationMethod: enumSetter(entity.ParseCooperationMethod, func(r *swiftx.CreateOrderRequest) *entity.CooperationMethod { return &r.CooperationMethod }),
+	FieldSelfPickupCode:    stringSetter(func(r *swiftx.CreateOrderRequest) *string { return &r.SelfPickupCode }),
+	FieldCustomerNote:      stringSetter(func(r *swiftx.CreateOrderRequest) *string { return &r.ShippingLabelInfo.CustomerNote }),
+	FieldExtSortingCode:    stringSetter(func(r *swiftx.CreateOrderRequest) *string { return &r.ShippingLabelInfo.ExtSortingCode }),
+
+	FieldSenderName:          stringSetter(func(r *swiftx.CreateOrderRequest) *string { return &r.PackageInfo.SenderAddress.Name }),
+	FieldSenderPhoneNumber:   stringSetter(func(r *swiftx.CreateOrderRequest) *string { return &r.PackageInfo.SenderAddress.PhoneNumber }),
+	FieldSenderRegionCode:    stringSetter(func(r *swiftx.CreateOrderRequest) *string { return &r.PackageInfo.SenderAddress.RegionCode }),
+	FieldSenderStateProvince: stringSetter(func(r *swiftx.CreateOrderRequest) *string { return &r.PackageInfo.SenderAddress.StateProvince }),
+	FieldSenderCity:          stringSetter(func(r *swiftx.CreateOrderRequest) *string { return &r.PackageInfo.SenderAddress.City }),
+	FieldSenderDistrict:      stringSetter(func(r *swiftx.CreateOrderRequest) *string { return &r.PackageInfo.SenderAddress.District }),
+	FieldSenderStreetAddress: stringSetter(func(r *swiftx.CreateOrderRequest) *string { return &r.PackageInfo.SenderAddress.StreetAddress }),
+	FieldSenderBuilding:      stringSetter(func(r *swiftx.CreateOrderRequest) *string { return &r.PackageInfo.SenderAddress.Building }),
+	FieldSenderPostalCode:    stringSetter(func(r *swiftx.CreateOrderRequest) *string { return &r.PackageInfo.SenderAddress.PostalCode }),
+
+	FieldRecipientName:          stringSetter(func(r *swiftx.CreateOrderRequest) *string { return &r.PackageInfo.RecipientAddress.Name }),
+	FieldRecipientPhoneNumber:   stringSetter(func(r *swiftx.CreateOrderRequest) *string { return &r.PackageInfo.RecipientAddress.PhoneNumber }),
+	FieldRecipientRegionCode:    stringSetter(func(r *swiftx.CreateOrderRequest) *string { return &r.PackageInfo.RecipientAddress.RegionCode }),
+	FieldRecipientStateProvince: stringSetter(func(r *swiftx.CreateOrderRequest) *string { return &r.PackageInfo.RecipientAddress.StateProvince }),
+	FieldRecipientCity:          stringSetter(func(r *swiftx.CreateOrderRequest) *string { return &r.PackageInfo.RecipientAddress.City }),
+	FieldRecipientDistrict:      stringSetter(func(r *swiftx.CreateOrderRequest) *string { return &r.PackageInfo.RecipientAddress.District }),
+	FieldRecipientStreetAddress: stringSetter(func(r *swiftx.CreateOrderRequest) *string { return &r.PackageInfo.RecipientAddress.StreetAddress }),
+	FieldRecipientBuilding:      stringSetter(func(r *swiftx.CreateOrderRequest) *string { return &r.PackageInfo.RecipientAddress.Building }),
+	FieldRecipientPostalCode:    stringSetter(func(r *swiftx.CreateOrderRequest) *string { return &r.PackageInfo.RecipientAddress.PostalCode }),
+
+	FieldUseImperialUnit: func(r *swiftx.CreateOrderRequest, v string) error {
+		b, err := parseBool(v)
+		if err != nil {
+			return err
+		}
+		r.PackageInfo.UseImperialUnit = b
+		return nil
+	},
+	FieldWeight: floatSetter(func(r *swiftx.CreateOrderRequest) *float64 { return &r.PackageInfo.Weight }),
+	FieldLength: floatSetter(func(r *swiftx.CreateOrderRequest) *float64 { return &r.PackageInfo.Length }),
+	FieldWidth:  floatSetter(func(r *swiftx.CreateOrderRequest) *float64 { return &r.PackageInfo.Width }),
+	FieldHeight: floatSetter(func(r *swiftx.CreateOrderRequest) *float64 { return &r.PackageInfo.Height }),
+	FieldValueAmount: func(r *swiftx.CreateOrderRequest, v string) error {
+		m, err := parseMoney(v, r.PackageInfo.Value.Currency)
+		if err != nil {
+			return err
+		}
+		r.PackageInfo.Value.Amount = m.Amount
+		return nil
+	},
+	FieldValueCurrency: enumSetter(entity.ParseCurrency, func(r *swiftx.CreateOrderRequest) *entity.Currency { return &r.PackageInfo.Value.Currency }),
+	FieldCustomerName:  stringSetter(func(r *swiftx.CreateOrderRequest) *string { return &r.PackageInfo.CustomerName }),
+	FieldStoreName:     stringSetter(func(r *swiftx.CreateOrderRequest) *string { return &r.PackageInfo.StoreName }),
+}
+
+type skuSetter func(sku *swiftx.CreateOrderPackageGoods, v string) error
+
+var skuSetters = map[string]skuSetter{
+	FieldSkuName: func(sku *swiftx.CreateOrderPackageGoods, v string) error {
+		sku.Name = v
+		return nil
+	},
+	FieldSkuQuantity: func(sku *swiftx.CreateOrderPackageGoods, v string) error {
+		n, err := strconv.Atoi(v)
+		if err != nil {
+			return fmt.Errorf("无效的数量 %s", v)
+		}
+		sku.Quantity = n
+		return nil
+	},
+	FieldSkuCode: func(sku *swiftx.CreateOrderPackageGoods, v string) error {
+		sku.Code = v
+		return nil
+	},
+	FieldSkuValue: func(sku *swiftx.CreateOrderPackageGoods, v string) error {
+		m, err := parseMoney(v, sku.Value.Currency)
+		if err != nil {
+			return err
+		}
+		sku.Value = entity.NullMoneyFrom(m)
+		return nil
+	},
+	FieldSkuCurrency: func(sku *swiftx.CreateOrderPackageGoods, v string) error {
+		currency, err := entity.ParseCurrency(v)
+		if err != nil {
+			return err
+		}
+		sku.Value.Currency = currency
+		return nil
+	},
+}
@@ -0,0 +1,250 @@
+package importer
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/hiscaler/swiftx-go"
+	"github.com/hiscaler/swiftx-go/entity"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestImporter() *CSVImporter {
+	im := NewCSVImporter(Mapping{
+		FieldOrderNumber:            "订单号",
+		FieldRecipientName:          "收件人",
+		FieldRecipientPhoneNumber:   "电话",
+		FieldRecipientRegionCode:    "国家",
+		FieldRecipientStateProvince: "州",
+		FieldRecipientCity:          "城市",
+		FieldRecipientStreetAddress: "地址",
+		FieldRecipientPostalCode:    "邮编",
+		FieldWeight:                 "重量",
+		FieldLength:                 "长",
+		FieldWidth:                  "宽",
+		FieldHeight:                 "高",
+		FieldValueAmount:            "总价值",
+		FieldSkuName:                "SKU 名称",
+		FieldSkuQuantity:            "数量",
+		FieldSkuCode:                "SKU",
+		FieldSkuValue:               "单价",
+	})
+	im.Template = swiftx.CreateOrderRequest{
+		OrderScope:        entity.OrderScopeDomestic,
+		ServiceType:       entity.ServiceTypeExp,
+		DeliveryMethod:    entity.DeliveryMethodHdy,
+		CooperationMethod: entity.CooperationMethodMerchant,
+		PackageInfo: swiftx.CreateOrderPackageInformation{
+			SenderAddress: swiftx.SenderAddress{
+				Name:          "ZEB2",
+				RegionCode:    "US",
+				StateProvince: "CA",
+				City:          "Ontario",
+				StreetAddress: "2078 E Francis Street",
+				PostalCode:    "91761",
+			},
+			Value: swiftx.Value{Money: entity.Money{Currency: entity.CurrencyUSD}},
+		},
+	}
+	return im
+}
+
+func TestCSVImporter_Import(t *testing.T) {
+	data := "\ufeff订单号,收件人,电话,国家,州,城市,地址,邮编,重量,长,宽,高,总价值,SKU 名称,数量,SKU,单价\n" +
+		"A001,Dak Jaech,3474473197,US,TX,Fort Worth,W1302 WELCH RD,76118,1.5,10,10,5,100,测试 SKU 1,1,SKU001,50\n" +
+		"A001,,,,,,,,,,,,,测试 SKU 2,2,SKU002,25\n" +
+		",,,,,,,,,,,,,,,,\n" +
+		"A002,Dak Jaech,3474473197,US,TX,Fort Worth,W1302 WELCH RD,76118,abc,10,10,5,100,测试 SKU 3,1,SKU003,50\n" +
+		",Dak Jaech,3474473197,US,TX,Fort Worth,W1302 WELCH RD,76118,1,10,10,5,100,测试 SKU 4,1,SKU004,50\n" +
+		"A003,Dak Jaech,,US,TX,Fort Worth,W1302 WELCH RD,76118,1,10,10,5,100,测试 SKU 5,1,SKU005,50\n" +
+		"A001,Other,,,,,,,,,,,,测试 SKU 6,1,SKU006,10\n" +
+		"A004,Dak Jaech,3474473197,US,TX,Fort Worth,W1302 WELCH RD,76118,2,10,10,5,80,测试 SKU 7,3,SKU007,\n"
+
+	result, err := newTestImporter().Import(strings.NewReader(data))
+	assert.NoError(t, err)
+	if assert.Len(t, result.Requests, 1) {
+		req := result.Requests[0]
+		assert.Equal(t, "A004", req.ShippingLabelInfo.OrderNumber)
+		assert.Equal(t, "ZEB2", req.PackageInfo.SenderAddress.Name)
+		assert.Equal(t, 2.0, req.PackageInfo.Weight)
+		if assert.Len(t, req.PackageInfo.SkuList, 1) {
+			assert.Equal(t, 3, req.PackageInfo.SkuList[0].Quantity)
+			assert.False(t, req.PackageInfo.SkuList[0].Value.Valid)
+		}
+	}
+
+	if assert.Len(t, result.Errors, 4) {
+		// 收件人与第 2 行不一致
+		assert.Equal(t, []int{2, 3, 8}, result.Errors[0].Rows)
+		assert.Equal(t, "A001", result.Errors[0].OrderNumber)
+		assert.Contains(t, result.Errors[0].Error(), "第 8 行")
+		// 无效的重量
+		assert.Equal(t, []int{5}, result.Errors[1].Rows)
+		assert.Contains(t, result.Errors[1].Error(), "无效的数值 abc")
+		// 订单号为空
+		assert.Equal(t, []int{6}, result.Errors[2].Rows)
+		// 收件人电话为空，未通过 Validate
+		assert.Equal(t, []int{7}, result.Errors[3].Rows)
+		assert.Equal(t, "A003", result.Errors[3].OrderNumber)
+	}
+}
+
+func TestCSVImporter_ImportGroupSku(t *testing.T) {
+	data := "订单号,收件人,电话,国家,州,城市,地址,邮编,重量,长,宽,高,总价值,SKU 名称,数量,SKU,单价\n" +
+		"A001,Dak Jaech,3474473197,US,TX,Fort Worth,W1302 WELCH RD,76118,1.5,10,10,5,100,测试 SKU 1,1,SKU001,50\n" +
+		"A001,Dak Jaech,,,,,,,,,,,,测试 SKU 2,2,SKU002,25\n"
+
+	result, err := newTestImporter().Import(strings.NewReader(data))
+	assert.NoError(t, err)
+	assert.Empty(t, result.Errors)
+	if assert.Len(t, result.Requests, 1) {
+		skus := result.Requests[0].PackageInfo.SkuList
+		if assert.Len(t, skus, 2) {
+			assert.Equal(t, "SKU001", skus[0].Code)
+			assert.Equal(t, "SKU002", skus[1].Code)
+			assert.Equal(t, entity.NewMoney(2500, ""), skus[1].Value.Money)
+		}
+	}
+}
+
+func TestCSVImporter_ImportEnum(t *testing.T) {
+	im := newTestImporter()
+	im.Mapping[FieldServiceType] = "服务类型"
+	im.Mapping[FieldDeliveryMethod] = "送货方式"
+	data := "订单号,收件人,电话,国家,州,城市,地址,邮编,重量,长,宽,高,总价值,SKU 名称,数量,SKU,单价,服务类型,送货方式\n" +
+		"A001,Dak Jaech,3474473197,US,TX,Fort Worth,W1302 WELCH RD,76118,1.5,10,10,5,100,测试 SKU 1,1,SKU001,50,特惠,hdy\n" +
+		"A002,Dak Jaech,3474473197,US,TX,Fort Worth,W1302 WELCH RD,76118,1.5,10,10,5,100,测试 SKU 2,1,SKU002,50,FAST,HDY\n"
+
+	result, err := im.Import(strings.NewReader(data))
+	assert.NoError(t, err)
+	if assert.Len(t, result.Requests, 1) {
+		assert.Equal(t, entity.ServiceTypeEco, result.Requests[0].ServiceType)
+		assert.Equal(t, entity.DeliveryMethodHdy, result.Requests[0].DeliveryMethod)
+	}
+	if assert.Len(t, result.Errors, 1) {
+		assert.Contains(t, result.Errors[0].Error(), "无效的服务类型 FAST")
+	}
+}
+
+func TestCSVImporter_ImportSkuCurrency(t *testing.T) {
+	im := newTestImporter()
+	im.Mapping[FieldSkuCurrency] = "币种"
+	data := "订单号,收件人,电话,国家,州,城市,地址,邮编,重量,长,宽,高,总价值,SKU 名称,数量,SKU,单价,币种\n" +
+		"A001,Dak Jaech,3474473197,US,TX,Fort Worth,W1302 WELCH RD,76118,1.5,10,10,5,100,测试 SKU 1,1,SKU001,50,cad\n" +
+		"A002,Dak Jaech,3474473197,US,TX,Fort Worth,W1302 WELCH RD,76118,1.5,10,10,5,100,测试 SKU 2,1,SKU002,,CAD\n"
+
+	result, err := im.Import(strings.NewReader(data))
+	assert.NoError(t, err)
+	if assert.Len(t, result.Requests, 1) {
+		sku := result.Requests[0].PackageInfo.SkuList[0]
+		assert.True(t, sku.Value.Valid)
+		assert.Equal(t, entity.NewMoney(5000, entity.CurrencyCAD), sku.Value.Money)
+	}
+	if assert.Len(t, result.Errors, 1) {
+		assert.Equal(t, "A002", result.Errors[0].OrderNumber)
+		assert.Contains(t, result.Errors[0].Error(), "缺少 sku_value")
+	}
+}
+
+func TestCSVImporter_ImportMissingOrderNumber(t *testing.T) {
+	_, err := NewCSVImporter(nil).Import(strings.NewReader("name,sku_name\nfoo,bar\n"))
+	assert.Error(t, err)
+
+	_, err = NewCSVImporter(Mapping{"unknown": "foo"}).Import(strings.NewReader("foo\nbar\n"))
+	assert.Error(t, err)
+}
+
+func TestParseFloat(t *testing.T) {
+	tests := []struct {
+		value   string
+		want    float64
+		wantErr bool
+	}{
+		{"1.5", 1.5, false},
+		{"1,234", 1234, false},
+		{"-1,234,567.25", -1234567.25, false},
+		{"1,5", 0, true},
+		{"1,23", 0, true},
+		{"1234,567", 0, true},
+		{",123", 0, true},
+		{"1.234,5", 0, true},
+		{".5", 0.5, false},
+		{"NaN", 0, true},
+		{"Inf", 0, true},
+		{"-Infinity", 0, true},
+		{"1e400", 0, true},
+		{"1e3", 0, true},
+		{strings.Repeat("9", 400), 0, true},
+		{"-", 0, true},
+		{".", 0, true},
+	}
+	for _, tt := range tests {
+		got, err := parseFloat(tt.value)
+		if tt.wantErr {
+			assert.Error(t, err, tt.value)
+			continue
+		}
+		if assert.NoError(t, err, tt.value) {
+			assert.Equal(t, tt.want, got, tt.value)
+		}
+	}
+}
+
+func TestCSVImporter_ImportErrorOrder(t *testing.T) {
+	data := "订单号,收件人,电话,国家,州,城市,地址,邮编,重量,长,宽,高,总价值,SKU 名称,数量,SKU,单价\n" +
+		"A001,Dak Jaech,3474473197,US,TX,Fort Worth,W1302 WELCH RD,76118,abc,x,y,z,100,测试 SKU 1,a,SKU001,b\n"
+
+	for i := 0; i < 20; i++ {
+		result, err := newTestImporter().Import(strings.NewReader(data))
+		assert.NoError(t, err)
+		if assert.Len(t, result.Errors, 1) {
+			assert.Contains(t, result.Errors[0].Error(), "无效的数值 abc")
+		}
+	}
+}
+
+func TestCSVImporter_ImportLimits(t *testing.T) {
+	im := newTestImporter()
+	im.Limits = swiftx.ServiceLimits{
+		entity.ServiceTypeExp: {
+			entity.UnitSystemMetric: {MaxWeight: 30},
+		},
+	}
+	data := "订单号,收件人,电话,国家,州,城市,地址,邮编,重量,长,宽,高,总价值,SKU 名称,数量,SKU,单价\n" +
+		"A001,Dak Jaech,3474473197,US,TX,Fort Worth,W1302 WELCH RD,76118,31,10,10,5,100,测试 SKU 1,1,SKU001,50\n" +
+		"A002,Dak Jaech,3474473197,US,TX,Fort Worth,W1302 WELCH RD,76118,29,10,10,5,100,测试 SKU 2,1,SKU002,50\n"
+
+	result, err := im.Import(strings.NewReader(data))
+	assert.NoError(t, err)
+	assert.Len(t, result.Requests, 1)
+	if assert.Len(t, result.Errors, 1) {
+		assert.Equal(t, "A001", result.Errors[0].OrderNumber)
+		assert.Contains(t, result.Errors[0].Error(), "重量 31 kg 超过标快（EXP）服务的限制 30 kg")
+	}
+}
+
+func TestParseMoney(t *testing.T) {
+	tests := []struct {
+		value   string
+		want    int64
+		wantErr bool
+	}{
+		{"12.5", 1250, false},
+		{"1,234.50", 123450, false},
+		{"-1,234", -123400, false},
+		{"1,5", 0, true},
+		{"1/3", 0, true},
+		{"1e3", 0, true},
+		{"NaN", 0, true},
+	}
+	for _, tt := range tests {
+		got, err := parseMoney(tt.value, entity.CurrencyUSD)
+		if tt.wantErr {
+			assert.Error(t, err, tt.value)
+			continue
+		}
+		if assert.NoError(t, err, tt.value) {
+			assert.Equal(t, entity.NewMoney(tt.want, entity.CurrencyUSD), got, tt.value)
+		}
+	}
+}
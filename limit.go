@@ -0,0 +1,115 @@
+package swiftx
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/hiscaler/swiftx-go/entity"
+)
+
+// PackageLimit 包裹重量和尺寸限制，单位由单位制决定（公斤、厘米或磅、英寸），值为 0 表示不限制
+type PackageLimit struct {
+	MaxWeight          float64 // 最大重量
+	MaxSide            float64 // 最长边的最大长度
+	MaxLengthPlusGirth float64 // 最长边 + 周长（2 × (宽 + 高)）的最大值
+}
+
+// in 将 from 单位制的限制换算为 to 单位制
+func (l PackageLimit) in(from, to entity.UnitSystem) PackageLimit {
+	if from == to {
+		return l
+	}
+	length := func(v float64) float64 {
+		return entity.Length{Value: v, Unit: from.LengthUnit()}.In(to.LengthUnit()).Value
+	}
+	return PackageLimit{
+		MaxWeight:          entity.Weight{Value: l.MaxWeight, Unit: from.WeightUnit()}.In(to.WeightUnit()).Value,
+		MaxSide:            length(l.MaxSide),
+		MaxLengthPlusGirth: length(l.MaxLengthPlusGirth),
+	}
+}
+
+// ServiceLimits 各服务类型在公制、英制下的包裹限制，某个单位制未设置时使用另一个单位制的限制换算
+//
+// SwiftX 按合同约定各服务类型的限制，请根据实际约定通过 WithServiceLimits 设置，
+// 创建订单时超出限制的包裹在本地校验时即被拒绝，不会发送到 SwiftX。
+//
+//	client := swiftx.NewClient(cfg, swiftx.WithServiceLimits(swiftx.ServiceLimits{
+//		entity.ServiceTypeExp: {
+//			entity.UnitSystemImperial: {MaxWeight: 70, MaxSide: 48, MaxLengthPlusGirth: 108},
+//			entity.UnitSystemMetric:   {MaxWeight: 30, MaxSide: 120, MaxLengthPlusGirth: 270},
+//		},
+//	}))
+type ServiceLimits map[entity.ServiceType]map[entity.UnitSystem]PackageLimit
+
+// Limit 返回服务类型在 system 单位制下的包裹限制
+func (l ServiceLimits) Limit(serviceType entity.ServiceType, system entity.UnitSystem) (PackageLimit, bool) {
+	limits, ok := l[serviceType]
+	if !ok {
+		return PackageLimit{}, false
+	}
+	if limit, ok := limits[system]; ok {
+		return limit, true
+	}
+	other := entity.UnitSystemImperial
+	if system.IsImperial() {
+		other = entity.UnitSystemMetric
+	}
+	if limit, ok := limits[other]; ok {
+		return limit.in(other, system), true
+	}
+	return PackageLimit{}, false
+}
+
+// Validate 检查订单包裹是否超过服务类型的重量和尺寸限制，未设置限制的服务类型不检查
+func (l ServiceLimits) Validate(request CreateOrderRequest) error {
+	if err := l.validatePackage(request.ServiceType, request.PackageInfo); err != nil {
+		return validation.Errors{"packageInfo": err}
+	}
+	return nil
+}
+
+// validatePackage 检查包裹是否超过 serviceType 服务的重量和尺寸限制
+func (l ServiceLimits) validatePackage(serviceType entity.ServiceType, info CreateOrderPackageInformation) error {
+	system := entity.UnitSystemOf(info.UseImperialUnit)
+	limit, ok := l.Limit(serviceType, system)
+	if !ok {
+		return nil
+	}
+	weightUnit, lengthUnit := string(system.WeightUnit()), string(system.LengthUnit())
+	exceeds := func(name string, value, max float64, unit string) validation.Rule {
+		return validation.When(max > 0 && value > max, validation.By(func(interface{}) error {
+			return errors.New(limitError(name, value, max, unit, serviceType))
+		}))
+	}
+	return validation.ValidateStruct(&info,
+		validation.Field(&info.Weight, exceeds("重量", info.Weight, limit.MaxWeight, weightUnit)),
+		validation.Field(&info.Length,
+			exceeds("长度", info.Length, limit.MaxSide, lengthUnit),
+			exceeds("最长边 + 周长", lengthPlusGirth(info.Length, info.Width, info.Height), limit.MaxLengthPlusGirth, lengthUnit),
+		),
+		validation.Field(&info.Width, exceeds("宽度", info.Width, limit.MaxSide, lengthUnit)),
+		validation.Field(&info.Height, exceeds("高度", info.Height, limit.MaxSide, lengthUnit)),
+	)
+}
+
+// lengthPlusGirth 返回最长边 + 周长（2 × (另外两边之和)）
+func lengthPlusGirth(length, width, height float64) float64 {
+	sides := []float64{length, width, height}
+	sort.Float64s(sides)
+	return sides[2] + 2*(sides[0]+sides[1])
+}
+
+// formatLimit 格式化重量或尺寸，保留 2 位小数，避免换算后的限制显示为 31.7514659 kg
+func formatLimit(v float64, unit string) string {
+	return strconv.FormatFloat(math.Round(v*100)/100, 'f', -1, 64) + " " + unit
+}
+
+// limitError 超出服务类型限制的错误信息，比如“重量 75 lb 超过标快（EXP）服务的限制 70 lb”
+func limitError(name string, value, max float64, unit string, serviceType entity.ServiceType) string {
+	return fmt.Sprintf("%s %s 超过%s（%s）服务的限制 %s", name, formatLimit(value, unit), serviceType.Label(entity.LanguageZh), serviceType, formatLimit(max, unit))
+}
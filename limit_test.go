@@ -0,0 +1,141 @@
+package swiftx
+
+import (
+	"testing"
+
+	"github.com/hiscaler/swiftx-go/config"
+	"github.com/hiscaler/swiftx-go/entity"
+	"github.com/stretchr/testify/assert"
+)
+
+func newLimitTestRequest() CreateOrderRequest {
+	return CreateOrderRequest{
+		OrderScope:        entity.OrderScopeDomestic,
+		ServiceType:       entity.ServiceTypeExp,
+		DeliveryMethod:    entity.DeliveryMethodHdy,
+		CooperationMethod: entity.CooperationMethodMerchant,
+		PackageInfo: CreateOrderPackageInformation{
+			SenderAddress:    SenderAddress{Name: "ZEB2", RegionCode: "US", StateProvince: "CA", City: "Ontario", StreetAddress: "2078 E Francis Street", PostalCode: "91761"},
+			RecipientAddress: RecipientAddress{Name: "Dak Jaech", PhoneNumber: "13474473197", RegionCode: "US", StateProvince: "TX", City: "Fort Worth", StreetAddress: "W1302 WELCH RD", PostalCode: "76118"},
+			UseImperialUnit:  true,
+			Weight:           75,
+			Length:           50,
+			Width:            20,
+			Height:           20,
+			Value:            Value{Money: entity.NewMoney(10000, entity.CurrencyUSD)},
+			SkuList:          []CreateOrderPackageGoods{{Name: "SKU 1", Quantity: 1}},
+		},
+		ShippingLabelInfo: ShippingLabelInformation{OrderNumber: "TEST-ORDER-1"},
+	}
+}
+
+func TestServiceLimits(t *testing.T) {
+	request := newLimitTestRequest()
+	// 默认不限制
+	var none ServiceLimits
+	assert.NoError(t, none.Validate(request))
+
+	limits := ServiceLimits{
+		entity.ServiceTypeExp: {
+			entity.UnitSystemImperial: {MaxWeight: 70, MaxSide: 48, MaxLengthPlusGirth: 130},
+		},
+	}
+	assert.NoError(t, request.Validate())
+	err := limits.Validate(request)
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "重量 75 lb 超过标快（EXP）服务的限制 70 lb")
+		assert.Contains(t, err.Error(), "长度 50 in 超过标快（EXP）服务的限制 48 in")
+	}
+	// 不检查其他服务类型
+	request.ServiceType = entity.ServiceTypeEco
+	assert.NoError(t, limits.Validate(request))
+	request.ServiceType = entity.ServiceTypeExp
+
+	// 最长边 + 周长：45 + 2 × (30 + 20) = 145
+	request.PackageInfo.Weight = 50
+	request.PackageInfo.Length = 20
+	request.PackageInfo.Width = 45
+	request.PackageInfo.Height = 30
+	err = limits.Validate(request)
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "最长边 + 周长 145 in 超过标快（EXP）服务的限制 130 in")
+	}
+
+	// 公制包裹使用换算后的限制：70 lb ≈ 31.75 kg
+	request.PackageInfo.UseImperialUnit = false
+	request.PackageInfo.Weight = 32
+	request.PackageInfo.Length = 100
+	request.PackageInfo.Width = 40
+	request.PackageInfo.Height = 30
+	err = limits.Validate(request)
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "重量 32 kg 超过标快（EXP）服务的限制 31.75 kg")
+	}
+	request.PackageInfo.Weight = 31
+	assert.NoError(t, limits.Validate(request))
+}
+
+func TestCreateOrderRequest_ValidateWithLimits(t *testing.T) {
+	request := newLimitTestRequest()
+	limits := ServiceLimits{
+		entity.ServiceTypeExp: {
+			entity.UnitSystemImperial: {MaxWeight: 70},
+		},
+	}
+	// Validate 使用默认限制（不限制）
+	assert.NoError(t, request.Validate())
+	assert.NoError(t, request.PackageInfo.Validate())
+	assert.NoError(t, request.ValidateWithLimits(nil))
+
+	err := request.ValidateWithLimits(limits)
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "packageInfo: (weight: 重量 75 lb 超过标快（EXP）服务的限制 70 lb.)")
+	}
+	err = request.PackageInfo.ValidateWithLimits(entity.ServiceTypeExp, limits)
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "重量 75 lb 超过标快（EXP）服务的限制 70 lb")
+	}
+	assert.NoError(t, request.PackageInfo.ValidateWithLimits(entity.ServiceTypeEco, limits))
+
+	// 基本校验失败时不检查限制
+	request.PackageInfo.SkuList = nil
+	err = request.PackageInfo.ValidateWithLimits(entity.ServiceTypeExp, limits)
+	if assert.Error(t, err) {
+		assert.NotContains(t, err.Error(), "超过")
+	}
+}
+
+func TestServiceLimits_Limit(t *testing.T) {
+	limits := ServiceLimits{
+		entity.ServiceTypeExp: {
+			entity.UnitSystemMetric:   {MaxWeight: 30, MaxSide: 120},
+			entity.UnitSystemImperial: {MaxWeight: 70, MaxSide: 48},
+		},
+		entity.ServiceTypeEco: {
+			entity.UnitSystemMetric: {MaxWeight: 10, MaxSide: 254},
+		},
+	}
+	limit, ok := limits.Limit(entity.ServiceTypeExp, entity.UnitSystemImperial)
+	assert.True(t, ok)
+	assert.Equal(t, PackageLimit{MaxWeight: 70, MaxSide: 48}, limit)
+
+	limit, ok = limits.Limit(entity.ServiceTypeEco, entity.UnitSystemImperial)
+	assert.True(t, ok)
+	assert.InDelta(t, 22.0462, limit.MaxWeight, 0.0001)
+	assert.InDelta(t, 100, limit.MaxSide, 0.0001)
+
+	_, ok = limits.Limit(entity.ServiceType("OTHER"), entity.UnitSystemMetric)
+	assert.False(t, ok)
+}
+
+func TestWithServiceLimits(t *testing.T) {
+	c := NewClient(config.Config{Env: entity.Test, Timeout: 5, AppKey: "key", AppSecret: "secret"}, WithServiceLimits(ServiceLimits{
+		entity.ServiceTypeExp: {
+			entity.UnitSystemImperial: {MaxWeight: 70},
+		},
+	}))
+	_, err := c.Services.Order.Create(ctx, newLimitTestRequest())
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "重量 75 lb 超过标快（EXP）服务的限制 70 lb")
+	}
+}
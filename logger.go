@@ -23,6 +23,15 @@ type logger struct {
 
 var _ Logger = (*logger)(nil)
 
+// debugLogger resty 调试模式使用的日志，调试输出使用 Info 级别，在默认的日志级别下也能输出
+type debugLogger struct {
+	*logger
+}
+
+func (l debugLogger) Debugf(msg string, args ...interface{}) {
+	l.Infof(msg, args...)
+}
+
 // isf Is valid Xprint format
 func isf(format string) bool {
 	fnIsFlag := func(c byte) bool {
@@ -0,0 +1,170 @@
+package swiftx
+
+import (
+	"math"
+
+	"github.com/hiscaler/swiftx-go/entity"
+)
+
+// measurementPrecision 转换单位后重量和尺寸保留的小数位数（向上取整）
+const measurementPrecision = 2
+
+// 体积重除数默认值，两者基本等价
+const (
+	DefaultDimDivisorMetric   = 5000 // 公制：cm³/kg
+	DefaultDimDivisorImperial = 139  // 英制：in³/lb
+)
+
+// PackageMeasurements 包裹重量和尺寸
+type PackageMeasurements struct {
+	Weight entity.Weight // 重量
+	Length entity.Length // 长度
+	Width  entity.Length // 宽度
+	Height entity.Length // 高度
+}
+
+// In 转换为 system 单位制
+func (m PackageMeasurements) In(system entity.UnitSystem) PackageMeasurements {
+	lengthUnit := system.LengthUnit()
+	return PackageMeasurements{
+		Weight: m.Weight.In(system.WeightUnit()),
+		Length: m.Length.In(lengthUnit),
+		Width:  m.Width.In(lengthUnit),
+		Height: m.Height.In(lengthUnit),
+	}
+}
+
+// Measurements 返回包裹的重量和尺寸，单位由 UseImperialUnit 决定
+func (m CreateOrderPackageInformation) Measurements() PackageMeasurements {
+	system := entity.UnitSystemOf(m.UseImperialUnit)
+	return PackageMeasurements{
+		Weight: entity.Weight{Value: m.Weight, Unit: system.WeightUnit()},
+		Length: entity.Length{Value: m.Length, Unit: system.LengthUnit()},
+		Width:  entity.Length{Value: m.Width, Unit: system.LengthUnit()},
+		Height: entity.Length{Value: m.Height, Unit: system.LengthUnit()},
+	}
+}
+
+// PackageMeasurementsBuilder 包裹重量和尺寸构建器，重量和尺寸可以使用任意单位，构建时统一转换为指定的单位制
+//
+//	swiftx.NewPackageMeasurementsBuilder(entity.UnitSystemImperial).
+//		Weight(entity.Kilograms(1.2)).
+//		Dimensions(entity.Centimeters(30), entity.Centimeters(20), entity.Inches(4)).
+//		ApplyTo(&request.PackageInfo)
+type PackageMeasurementsBuilder struct {
+	system       entity.UnitSystem
+	measurements PackageMeasurements
+}
+
+// NewPackageMeasurementsBuilder 创建包裹重量和尺寸构建器，system 为构建结果使用的单位制
+func NewPackageMeasurementsBuilder(system entity.UnitSystem) *PackageMeasurementsBuilder {
+	return &PackageMeasurementsBuilder{system: system}
+}
+
+// Weight 设置重量
+func (b *PackageMeasurementsBuilder) Weight(weight entity.Weight) *PackageMeasurementsBuilder {
+	b.measurements.Weight = weight
+	return b
+}
+
+// Dimensions 设置长、宽、高
+func (b *PackageMeasurementsBuilder) Dimensions(length, width, height entity.Length) *PackageMeasurementsBuilder {
+	b.measurements.Length = length
+	b.measurements.Width = width
+	b.measurements.Height = height
+	return b
+}
+
+// Build 返回转换为指定单位制后的重量和尺寸，保留 2 位小数（向上取整）
+func (b *PackageMeasurementsBuilder) Build() PackageMeasurements {
+	m := b.measurements.In(b.system)
+	m.Weight.Value = roundUp(m.Weight.Value, measurementPrecision)
+	m.Length.Value = roundUp(m.Length.Value, measurementPrecision)
+	m.Width.Value = roundUp(m.Width.Value, measurementPrecision)
+	m.Height.Value = roundUp(m.Height.Value, measurementPrecision)
+	return m
+}
+
+// ApplyTo 设置包裹的单位制、重量和尺寸
+func (b *PackageMeasurementsBuilder) ApplyTo(info *CreateOrderPackageInformation) {
+	m := b.Build()
+	info.UseImperialUnit = b.system.IsImperial()
+	info.Weight = m.Weight.Value
+	info.Length = m.Length.Value
+	info.Width = m.Width.Value
+	info.Height = m.Height.Value
+}
+
+// roundUp 保留 precision 位小数，向上取整。先舍入到更高精度，避免单位换算的浮点误差导致多进一位（比如 25.4000000001）
+func roundUp(v float64, precision int) float64 {
+	scale := math.Pow10(precision)
+	return math.Ceil(math.Round(v*scale*1e4)/1e4) / scale
+}
+
+// roundUpTo 按 increment 向上取整，increment 小于等于 0 时不取整
+func roundUpTo(v, increment float64) float64 {
+	if increment <= 0 {
+		return v
+	}
+	return math.Ceil(math.Round(v/increment*1e6)/1e6) * increment
+}
+
+// DimWeightCalculator 体积重计算，体积重 = 长 × 宽 × 高 ÷ 除数
+type DimWeightCalculator struct {
+	System    entity.UnitSystem // 计算使用的单位制，为空时使用公制
+	Divisor   float64           // 除数，公制为 cm³/kg，英制为 in³/lb，为 0 时使用 DefaultDimDivisorMetric 或 DefaultDimDivisorImperial
+	Increment float64           // 重量向上取整的步长（单位与 System 一致），比如 1 表示不足 1 磅按 1 磅计算，为 0 时不取整
+}
+
+// BillableWeight 计费重量
+type BillableWeight struct {
+	Actual      entity.Weight // 实际重量
+	Dimensional entity.Weight // 体积重
+	Billable    entity.Weight // 计费重量，实际重量和体积重中较大者
+}
+
+// DimensionalApplies 是否按体积重计费
+func (w BillableWeight) DimensionalApplies() bool {
+	return w.Dimensional.Value > w.Actual.Value
+}
+
+func (c DimWeightCalculator) system() entity.UnitSystem {
+	if c.System == "" {
+		return entity.UnitSystemMetric
+	}
+	return c.System
+}
+
+func (c DimWeightCalculator) divisor() float64 {
+	if c.Divisor > 0 {
+		return c.Divisor
+	}
+	if c.system().IsImperial() {
+		return DefaultDimDivisorImperial
+	}
+	return DefaultDimDivisorMetric
+}
+
+// DimWeight 计算体积重（按 Increment 向上取整）
+func (c DimWeightCalculator) DimWeight(m PackageMeasurements) entity.Weight {
+	system := c.system()
+	m = m.In(system)
+	volume := m.Length.Value * m.Width.Value * m.Height.Value
+	return entity.Weight{Value: roundUpTo(volume/c.divisor(), c.Increment), Unit: system.WeightUnit()}
+}
+
+// BillableWeight 计算实际重量、体积重和计费重量（均按 Increment 向上取整）
+func (c DimWeightCalculator) BillableWeight(m PackageMeasurements) BillableWeight {
+	system := c.system()
+	actual := m.Weight.In(system.WeightUnit())
+	actual.Value = roundUpTo(actual.Value, c.Increment)
+	w := BillableWeight{
+		Actual:      actual,
+		Dimensional: c.DimWeight(m),
+	}
+	w.Billable = w.Actual
+	if w.DimensionalApplies() {
+		w.Billable = w.Dimensional
+	}
+	return w
+}
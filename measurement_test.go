@@ -0,0 +1,69 @@
+package swiftx
+
+import (
+	"testing"
+
+	"github.com/hiscaler/swiftx-go/entity"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPackageMeasurementsBuilder(t *testing.T) {
+	var info CreateOrderPackageInformation
+	NewPackageMeasurementsBuilder(entity.UnitSystemMetric).
+		Weight(entity.Pounds(2)).
+		Dimensions(entity.Inches(10), entity.Centimeters(20), entity.Inches(1.5)).
+		ApplyTo(&info)
+	assert.False(t, info.UseImperialUnit)
+	assert.Equal(t, 0.91, info.Weight)
+	assert.Equal(t, 25.4, info.Length)
+	assert.Equal(t, 20.0, info.Width)
+	assert.Equal(t, 3.81, info.Height)
+
+	NewPackageMeasurementsBuilder(entity.UnitSystemImperial).
+		Weight(entity.Kilograms(1)).
+		Dimensions(entity.Centimeters(25.4), entity.Centimeters(10), entity.Inches(2)).
+		ApplyTo(&info)
+	assert.True(t, info.UseImperialUnit)
+	assert.Equal(t, 2.21, info.Weight)
+	assert.Equal(t, 10.0, info.Length)
+	assert.Equal(t, 3.94, info.Width)
+	assert.Equal(t, 2.0, info.Height)
+
+	m := info.Measurements()
+	assert.Equal(t, entity.Pounds(2.21), m.Weight)
+	assert.Equal(t, entity.Inches(10), m.Length)
+}
+
+func TestDimWeightCalculator(t *testing.T) {
+	m := PackageMeasurements{
+		Weight: entity.Pounds(3),
+		Length: entity.Inches(12),
+		Width:  entity.Inches(12),
+		Height: entity.Inches(12),
+	}
+
+	c := DimWeightCalculator{System: entity.UnitSystemImperial, Increment: 1}
+	w := c.BillableWeight(m)
+	assert.Equal(t, entity.Pounds(3), w.Actual)
+	assert.Equal(t, entity.Pounds(13), w.Dimensional) // 1728 / 139 = 12.43
+	assert.Equal(t, entity.Pounds(13), w.Billable)
+	assert.True(t, w.DimensionalApplies())
+
+	c.Divisor = 166
+	assert.Equal(t, entity.Pounds(11), c.DimWeight(m)) // 1728 / 166 = 10.41
+
+	m.Weight = entity.Pounds(20)
+	w = c.BillableWeight(m)
+	assert.False(t, w.DimensionalApplies())
+	assert.Equal(t, entity.Pounds(20), w.Billable)
+
+	// 公制
+	w = DimWeightCalculator{}.BillableWeight(PackageMeasurements{
+		Weight: entity.Kilograms(1),
+		Length: entity.Centimeters(50),
+		Width:  entity.Centimeters(40),
+		Height: entity.Centimeters(30),
+	})
+	assert.Equal(t, entity.Kilograms(12), w.Dimensional)
+	assert.Equal(t, entity.Kilograms(12), w.Billable)
+}
@@ -0,0 +1,214 @@
+package swiftx
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/url"
+	"os"
+	"time"
+
+	"github.com/go-resty/resty/v2"
+	"github.com/hiscaler/swiftx-go/entity"
+	"github.com/hiscaler/swiftx-go/response"
+)
+
+// Call API 调用信息
+//
+// 中间件可以在调用 next 之前修改请求相关的字段，调用 next 之后读取响应相关的字段。
+type Call struct {
+	Endpoint string      // 接口名称，比如 createOrderAndGetLabelPdfBase64
+	Method   string      // HTTP 方法
+	Query    url.Values  // 查询参数
+	Body     any         // 请求内容
+	Header   http.Header // 额外的请求头
+	Result   any         // 响应结果（指针），调用完成后为解码后的结果
+
+	RequestID string // 调用方指定的请求 ID（CallRequestID）
+	Debug     *bool  // 是否输出请求和响应内容（CallDebug），为 nil 时使用 Config.Debug
+	DryRun    bool   // 是否为试运行模式下不会发送到 SwiftX 的调用（WithDryRun），指标等中间件应忽略该调用
+
+	StatusCode   int           // HTTP 状态码，请求未发送成功时为 0
+	SignedHeader http.Header   // 实际发送的请求头（包含签名）
+	Attempts     int           // 请求次数（包括重试）
+	Latency      time.Duration // 耗时（包括限流等待和重试）
+}
+
+// TrackingNumbers 返回请求中的 SwiftX 订单号（批量查询时为多个）
+func (c *Call) TrackingNumbers() []string {
+	switch body := c.Body.(type) {
+	case map[string][]string:
+		return body["trackingNoList"]
+	case map[string]string:
+		if v, ok := body["trackingNo"]; ok {
+			return []string{v}
+		}
+	}
+	return nil
+}
+
+// ItemResults 返回批量查询（Tracking、Postage）响应中每个订单号的业务结果，其他接口返回 nil
+//
+// 批量查询的整体响应成功时，单个订单号仍可能查询失败（Success 为 false）。
+func (c *Call) ItemResults() []response.Result {
+	var results []response.Result
+	switch items := c.Result.(type) {
+	case *[]entity.TrackingResult:
+		results = make([]response.Result, len(*items))
+		for i, item := range *items {
+			results[i] = item.Result
+		}
+	case *[]postageResult:
+		results = make([]response.Result, len(*items))
+		for i, item := range *items {
+			results[i] = item.Result
+		}
+	}
+	return results
+}
+
+// 错误分类
+const (
+	ErrorCategoryNone      = ""             // 无错误
+	ErrorCategoryCanceled  = "canceled"     // 调用被取消
+	ErrorCategoryTimeout   = "timeout"      // 超时
+	ErrorCategoryNetwork   = "network"      // 网络错误
+	ErrorCategoryAuth      = "auth"         // 身份验证或授权失败（401、403）
+	ErrorCategoryRateLimit = "rate_limit"   // 超出速率限制（429）
+	ErrorCategoryClient    = "client"       // 其他 4xx 错误
+	ErrorCategoryServer    = "server"       // 5xx 错误
+	ErrorCategoryBusiness  = "business"     // 业务错误（ResultError）
+	ErrorCategoryCircuit   = "circuit_open" // 熔断器已打开，请求未发送
+)
+
+// ErrorCategory 返回调用错误的分类，用于统计
+func (c *Call) ErrorCategory(err error) string {
+	if err == nil {
+		return ErrorCategoryNone
+	}
+
+	var resultErr *ResultError
+	switch {
+	case errors.Is(err, ErrCircuitOpen):
+		return ErrorCategoryCircuit
+	case errors.As(err, &resultErr):
+		return ErrorCategoryBusiness
+	case c.StatusCode == http.StatusUnauthorized || c.StatusCode == http.StatusForbidden:
+		return ErrorCategoryAuth
+	case c.StatusCode == http.StatusTooManyRequests:
+		return ErrorCategoryRateLimit
+	case c.StatusCode >= 500:
+		return ErrorCategoryServer
+	case c.StatusCode >= 400:
+		return ErrorCategoryClient
+	case errors.Is(err, context.Canceled):
+		return ErrorCategoryCanceled
+	case errors.Is(err, context.DeadlineExceeded) || os.IsTimeout(err):
+		return ErrorCategoryTimeout
+	}
+	return ErrorCategoryNetwork
+}
+
+// Handler 处理 API 调用
+type Handler interface {
+	Handle(ctx context.Context, call *Call) error
+}
+
+// HandlerFunc 函数形式的 Handler
+type HandlerFunc func(ctx context.Context, call *Call) error
+
+func (f HandlerFunc) Handle(ctx context.Context, call *Call) error {
+	return f(ctx, call)
+}
+
+// Middleware 中间件，可用于追踪、审计、注入请求头等
+type Middleware func(next Handler) Handler
+
+// WithMiddleware 添加中间件，先添加的中间件在外层
+func WithMiddleware(middlewares ...Middleware) Option {
+	return func(c *Client) {
+		c.middlewares = append(c.middlewares, middlewares...)
+	}
+}
+
+// ResultError 接口返回的业务错误（response.Result.Success 为 false）
+type ResultError struct {
+	Message string
+}
+
+func (e *ResultError) Error() string {
+	return e.Message
+}
+
+// chain 将中间件和 handler 组合成调用链
+func chain(handler Handler, middlewares []Middleware) Handler {
+	for i := len(middlewares) - 1; i >= 0; i-- {
+		handler = middlewares[i](handler)
+	}
+	return handler
+}
+
+// restyHandler 使用 resty 发送请求
+type restyHandler struct {
+	httpClient *resty.Client
+}
+
+func (h restyHandler) Handle(ctx context.Context, call *Call) error {
+	ctx, signed := withSignedRequest(ctx)
+	request := h.httpClient.R().SetContext(ctx)
+	if call.Query != nil {
+		request.SetQueryParamsFromValues(call.Query)
+	}
+	if call.Body != nil {
+		request.SetBody(call.Body)
+	}
+	for key, values := range call.Header {
+		for _, value := range values {
+			request.Header.Add(key, value)
+		}
+	}
+	if call.Result != nil {
+		request.SetResult(call.Result)
+	}
+	if call.Debug != nil {
+		request.SetDebug(*call.Debug)
+	}
+
+	start := time.Now()
+	resp, err := request.Execute(call.Method, "/"+call.Endpoint)
+	call.Latency = time.Since(start)
+	if resp != nil {
+		call.StatusCode = resp.StatusCode()
+		call.Attempts = resp.Request.Attempt
+		if resp.RawResponse != nil && resp.RawResponse.Request != nil {
+			call.SignedHeader = resp.RawResponse.Request.Header.Clone()
+		}
+	}
+	if call.SignedHeader == nil {
+		// 请求失败没有响应时使用最近一次签名后的请求
+		if r := signed.Load(); r != nil {
+			call.SignedHeader = r.Header.Clone()
+		}
+	}
+	if err = recheckError(resp, err); err != nil {
+		return err
+	}
+	return resultError(call.Result)
+}
+
+// resultError 检查响应结果中的业务状态
+func resultError(result any) error {
+	var r response.Result
+	switch v := result.(type) {
+	case *response.Result:
+		r = *v
+	case *CreateOrderResult:
+		r = v.Result
+	default:
+		return nil
+	}
+	if r.Success {
+		return nil
+	}
+	return &ResultError{Message: r.Message}
+}
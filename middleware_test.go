@@ -0,0 +1,95 @@
+package swiftx
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/hiscaler/swiftx-go/response"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClient_Middleware(t *testing.T) {
+	var received http.Header
+	var calls []*Call
+	var errs []error
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		received = r.Header.Clone()
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Path == "/api/v2/openapi/"+EndpointCancelOrder {
+			fmt.Fprint(w, `{"success":false,"message":"订单已揽收"}`)
+			return
+		}
+		fmt.Fprint(w, r.URL.Query().Get("i"))
+	}, WithMiddleware(
+		// 注入请求头
+		func(next Handler) Handler {
+			return HandlerFunc(func(ctx context.Context, call *Call) error {
+				if call.Header == nil {
+					call.Header = http.Header{}
+				}
+				call.Header.Set("X-Trace-Id", "trace-1")
+				return next.Handle(ctx, call)
+			})
+		},
+		// 记录调用结果
+		func(next Handler) Handler {
+			return HandlerFunc(func(ctx context.Context, call *Call) error {
+				err := next.Handle(ctx, call)
+				calls = append(calls, call)
+				errs = append(errs, err)
+				return err
+			})
+		},
+	))
+
+	n, err := c.Services.Ping.Pong(ctx, 3)
+	assert.NoError(t, err)
+	assert.Equal(t, 3, n)
+	assert.Equal(t, "trace-1", received.Get("X-Trace-Id"))
+
+	_, err = c.Services.Order.Cancel(ctx, "SWX1")
+	var resultErr *ResultError
+	assert.True(t, errors.As(err, &resultErr))
+	assert.EqualError(t, err, "订单已揽收")
+
+	if assert.Len(t, calls, 2) {
+		call := calls[0]
+		assert.Equal(t, EndpointPingPong, call.Endpoint)
+		assert.Equal(t, http.StatusOK, call.StatusCode)
+		assert.Equal(t, 1, call.Attempts)
+		assert.Greater(t, int64(call.Latency), int64(0))
+		assert.Equal(t, 3, *call.Result.(*int))
+		assert.NotEmpty(t, call.SignedHeader.Get(HeaderSignature))
+		assert.Equal(t, "trace-1", call.SignedHeader.Get("X-Trace-Id"))
+
+		assert.Equal(t, EndpointCancelOrder, calls[1].Endpoint)
+		assert.False(t, calls[1].Result.(*response.Result).Success)
+		assert.True(t, errors.As(errs[1], &resultErr))
+	}
+}
+
+func TestCall_ErrorCategory(t *testing.T) {
+	tests := []struct {
+		statusCode int
+		err        error
+		expected   string
+	}{
+		{http.StatusOK, nil, ErrorCategoryNone},
+		{http.StatusOK, &ResultError{Message: "订单不存在"}, ErrorCategoryBusiness},
+		{http.StatusUnauthorized, errors.New("unauthorized"), ErrorCategoryAuth},
+		{http.StatusTooManyRequests, errors.New("too many requests"), ErrorCategoryRateLimit},
+		{http.StatusBadRequest, errors.New("bad request"), ErrorCategoryClient},
+		{http.StatusBadGateway, errors.New("bad gateway"), ErrorCategoryServer},
+		{0, context.Canceled, ErrorCategoryCanceled},
+		{0, fmt.Errorf("get: %w", context.DeadlineExceeded), ErrorCategoryTimeout},
+		{0, errors.New("connection refused"), ErrorCategoryNetwork},
+		{0, &CircuitOpenError{Endpoint: EndpointPingPong}, ErrorCategoryCircuit},
+	}
+	for _, test := range tests {
+		call := &Call{StatusCode: test.statusCode}
+		assert.Equal(t, test.expected, call.ErrorCategory(test.err), "%d %v", test.statusCode, test.err)
+	}
+}
@@ -0,0 +1,76 @@
+package swiftx
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"golang.org/x/time/rate"
+)
+
+// Option Client 选项
+type Option func(c *Client)
+
+// WithCredentialProvider 设置凭证提供者，默认使用配置中的 AppKey 和 AppSecret（配置了 AppSecretFile 时使用 FileCredentials）
+func WithCredentialProvider(provider CredentialProvider) Option {
+	return func(c *Client) {
+		c.credentials.provider = provider
+	}
+}
+
+// WithCredentialGracePeriod 设置密钥轮换的宽限期
+//
+// 宽限期内继续使用旧密钥签名，旧密钥身份验证失败后再使用新密钥重试。
+func WithCredentialGracePeriod(d time.Duration) Option {
+	return func(c *Client) {
+		c.credentials.gracePeriod = d
+	}
+}
+
+// RateLimiter 限流器，每次请求（包括重试）发送前调用 Wait，*rate.Limiter 实现了该接口
+type RateLimiter interface {
+	Wait(ctx context.Context) error
+}
+
+// NewRateLimiter 创建令牌桶限流器，rps 为每秒请求数，burst 为允许的突发请求数
+func NewRateLimiter(rps float64, burst int) RateLimiter {
+	if burst <= 0 {
+		burst = 1
+	}
+	return rate.NewLimiter(rate.Limit(rps), burst)
+}
+
+// WithRateLimiter 设置限流器，多个 Client 共用同一个限流器时共享请求配额
+func WithRateLimiter(limiter RateLimiter) Option {
+	return func(c *Client) {
+		c.rateLimiter = limiter
+	}
+}
+
+// WithTransport 设置 HTTP Transport，多个 Client 可以共用同一个 Transport 以复用连接
+func WithTransport(transport http.RoundTripper) Option {
+	return func(c *Client) {
+		c.transport = transport
+	}
+}
+
+// WithSigner 设置请求签名，可用于注入时钟和随机数生成器
+func WithSigner(signer Signer) Option {
+	return func(c *Client) {
+		c.signer = signer
+	}
+}
+
+// WithClockSkewCompensation 设置是否根据服务器时间（响应的 Date 头）校正签名时间，默认启用
+func WithClockSkewCompensation(enabled bool) Option {
+	return func(c *Client) {
+		c.compensate = enabled
+	}
+}
+
+// WithServiceLimits 设置创建订单时检查的各服务类型的包裹重量和尺寸限制，默认不限制
+func WithServiceLimits(limits ServiceLimits) Option {
+	return func(c *Client) {
+		c.limits = limits
+	}
+}
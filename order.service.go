@@ -2,10 +2,11 @@ package swiftx
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
+	"net/http"
 
 	validation "github.com/go-ozzo/ozzo-validation/v4"
-	"github.com/go-ozzo/ozzo-validation/v4/is"
 	"github.com/hiscaler/swiftx-go/entity"
 	"github.com/hiscaler/swiftx-go/response"
 	"gopkg.in/guregu/null.v4"
@@ -20,37 +21,46 @@ type SenderAddress = entity.Address
 // RecipientAddress 收货地址
 type RecipientAddress = entity.Address
 
-// Value 金额
+// Value 金额，JSON 格式为 {"amount":100,"currencyCode":"USD"}
 type Value struct {
-	Amount       float64 `json:"amount"`       // 金额数值
-	CurrencyCode string  `json:"currencyCode"` // 币种代码（Enum: "USD" "CAD" "HKD" "CNY"）
+	entity.Money
 }
 
 // Validate 费用验证
 func (m Value) Validate() error {
-	return validation.ValidateStruct(&m,
-		validation.Field(&m.Amount,
+	return validation.ValidateStruct(&m.Money,
+		validation.Field(&m.Money.Amount,
 			validation.Required.Error("金额不能为空"),
-			validation.Min(0.0).Error("金额不能小于 0"),
-		),
-		validation.Field(&m.CurrencyCode,
-			validation.Required.Error("币种代码不能为空"),
-			is.CurrencyCode.ErrorObject(
-				validation.NewError(
-					"422",
-					"无效的币种代码 {{.value}}").
-					SetParams(map[string]interface{}{"value": m.CurrencyCode}),
-			),
+			validation.Min(int64(0)).Error("金额不能小于 0"),
 		),
+		// 币种代码的可选值由 entity.Currency 的 Validate 方法校验
+		validation.Field(&m.Money.Currency, validation.Required.Error("币种代码不能为空")),
 	)
 }
 
+type valueJSON struct {
+	Amount       json.RawMessage `json:"amount"`
+	CurrencyCode entity.Currency `json:"currencyCode"`
+}
+
+func (m Value) MarshalJSON() ([]byte, error) {
+	return json.Marshal(valueJSON{Amount: json.RawMessage(m.MarshalNumber()), CurrencyCode: m.Currency})
+}
+
+func (m *Value) UnmarshalJSON(b []byte) error {
+	var v valueJSON
+	if err := json.Unmarshal(b, &v); err != nil {
+		return err
+	}
+	m.Currency = v.CurrencyCode
+	return m.UnmarshalNumber(v.Amount)
+}
+
 type CreateOrderPackageGoods struct {
-	Name         string     `json:"name"`                   // SKU 名称
-	Quantity     int        `json:"quantity"`               // SKU 数量
-	Code         string     `json:"code,omitempty"`         // SKU 商品编码
-	Value        null.Float `json:"value,omitempty"`        // SKU 单价
-	CurrencyCode string     `json:"currencyCode,omitempty"` // 币种代码（Enum: "USD" "CAD" "HKD" "CNY"）
+	Name     string           `json:"name"`           // SKU 名称
+	Quantity int              `json:"quantity"`       // SKU 数量
+	Code     string           `json:"code,omitempty"` // SKU 商品编码
+	Value    entity.NullMoney `json:"-"`              // SKU 单价和币种（Enum: "USD" "CAD" "HKD" "CNY"），对应 JSON 中的 value 和 currencyCode
 }
 
 // Validate SKU商品验证
@@ -67,20 +77,52 @@ func (m CreateOrderPackageGoods) Validate() error {
 		validation.Field(&m.Code,
 			validation.When(m.Code != "", validation.Length(1, 100).Error("SKU 商品编码长度不能大于 {{.max}} 个字符")),
 		),
-		validation.Field(&m.Value, validation.When(m.Value.Valid, validation.Min(0.0).Error("SKU 单价不能小于 {{.min}}"))),
-		validation.Field(&m.CurrencyCode,
-			validation.When(m.CurrencyCode != "",
-				is.CurrencyCode.ErrorObject(
-					validation.NewError(
-						"422",
-						"无效的币种代码 {{.value}}").
-						SetParams(map[string]any{"value": m.CurrencyCode}),
-				),
-			),
-		),
+		validation.Field(&m.Value, validation.When(m.Value.Valid, validation.By(func(value interface{}) error {
+			v := value.(entity.NullMoney)
+			if v.IsNegative() {
+				return errors.New("SKU 单价不能小于 0")
+			}
+			if v.Currency != "" {
+				return v.Currency.Validate()
+			}
+			return nil
+		}))),
 	)
 }
 
+type goodsJSON struct {
+	Name         string          `json:"name"`
+	Quantity     int             `json:"quantity"`
+	Code         string          `json:"code,omitempty"`
+	Value        json.RawMessage `json:"value,omitempty"`
+	CurrencyCode entity.Currency `json:"currencyCode,omitempty"`
+}
+
+func (m CreateOrderPackageGoods) MarshalJSON() ([]byte, error) {
+	v := goodsJSON{Name: m.Name, Quantity: m.Quantity, Code: m.Code}
+	if m.Value.Valid {
+		v.Value = json.RawMessage(m.Value.MarshalNumber())
+		v.CurrencyCode = m.Value.Currency
+	}
+	return json.Marshal(v)
+}
+
+func (m *CreateOrderPackageGoods) UnmarshalJSON(b []byte) error {
+	var v goodsJSON
+	if err := json.Unmarshal(b, &v); err != nil {
+		return err
+	}
+	*m = CreateOrderPackageGoods{Name: v.Name, Quantity: v.Quantity, Code: v.Code}
+	if len(v.Value) > 0 && string(v.Value) != "null" {
+		m.Value.Currency = v.CurrencyCode
+		if err := m.Value.UnmarshalNumber(v.Value); err != nil {
+			return err
+		}
+		m.Value.Valid = true
+	}
+	return nil
+}
+
 // CreateOrderPackageInformation 包裹信息
 type CreateOrderPackageInformation struct {
 	SenderAddress    SenderAddress             `json:"senderAddress"`    // 地址信息。注意：电话号码对于寄件人地址是可选的。
@@ -97,8 +139,14 @@ type CreateOrderPackageInformation struct {
 }
 
 // Validate 包裹信息验证
+// Validate 包裹信息校验，不检查服务类型的重量和尺寸限制
 func (m CreateOrderPackageInformation) Validate() error {
-	return validation.ValidateStruct(&m,
+	return m.ValidateWithLimits("", nil)
+}
+
+// ValidateWithLimits 包裹信息校验，并检查是否超过 limits 中 serviceType 服务的重量和尺寸限制
+func (m CreateOrderPackageInformation) ValidateWithLimits(serviceType entity.ServiceType, limits ServiceLimits) error {
+	err := validation.ValidateStruct(&m,
 		validation.Field(&m.SenderAddress, validation.Required.Error("发货地址不能为空"), validation.By(func(value interface{}) error {
 			address, ok := value.(SenderAddress)
 			if !ok {
@@ -134,6 +182,33 @@ func (m CreateOrderPackageInformation) Validate() error {
 			return value.(CreateOrderPackageGoods).Validate()
 		}))),
 	)
+	if err != nil {
+		return err
+	}
+	return limits.validatePackage(serviceType, m)
+}
+
+// SkuValueTotal 返回 SKU 单价乘以数量的合计，未设置币种的 SKU 使用包裹总价值的币种，
+// 币种不一致时返回 entity.ErrCurrencyMismatch，未设置单价的 SKU 不计入合计
+func (m CreateOrderPackageInformation) SkuValueTotal() (entity.Money, error) {
+	total := entity.Money{Currency: m.Value.Currency}
+	for _, sku := range m.SkuList {
+		if !sku.Value.Valid {
+			continue
+		}
+		value := sku.Value.Money
+		if value.Currency == "" {
+			value.Currency = m.Value.Currency
+		}
+		amount, err := value.Mul(int64(sku.Quantity))
+		if err != nil {
+			return entity.Money{}, err
+		}
+		if total, err = total.Add(amount); err != nil {
+			return entity.Money{}, err
+		}
+	}
+	return total, nil
 }
 
 // InsuranceService 保险服务配置
@@ -192,10 +267,10 @@ func (m ShippingLabelInformation) Validate() error {
 }
 
 type CreateOrderRequest struct {
-	OrderScope        string                        `json:"orderScope"`        // 订单类型,例如 DOMESTIC（国内）或 INTERNATIONAL（国际)
-	ServiceType       string                        `json:"serviceType"`       // 服务类型， ECO-特惠 EXP-标快。建议选择EXP-标快
-	DeliveryMethod    string                        `json:"deliveryMethod"`    // 送货方式，HDY-上门派送 SPU-自提
-	CooperationMethod string                        `json:"cooperationMethod"` // 合作方式：PLATFORM-平台、MERCHANT-商家、WESTERN_POST-西邮
+	OrderScope        entity.OrderScope             `json:"orderScope"`        // 订单类型,例如 DOMESTIC（国内）或 INTERNATIONAL（国际)
+	ServiceType       entity.ServiceType            `json:"serviceType"`       // 服务类型， ECO-特惠 EXP-标快。建议选择EXP-标快
+	DeliveryMethod    entity.DeliveryMethod         `json:"deliveryMethod"`    // 送货方式，HDY-上门派送 SPU-自提
+	CooperationMethod entity.CooperationMethod      `json:"cooperationMethod"` // 合作方式：PLATFORM-平台、MERCHANT-商家、WESTERN_POST-西邮
 	ClientCode        string                        `json:"clientCode"`        // 客户代码，默认留空，使用场景需联系商务支持
 	EntryPostalCode   string                        `json:"entryPostalCode"`   // 交邮点邮编，默认留空，使用场景需联系商务支持
 	ReferenceNo       string                        `json:"referenceNo"`       // 引用单号，默认留空，使用场景需联系商务支持
@@ -211,26 +286,21 @@ type CreateOrderRequest struct {
 	} `json:"extraInfo"` // 额外信息,字段可由客户自行扩展，对应字符串长度小于 4096 个字符
 }
 
+// Validate 订单校验，不检查服务类型的重量和尺寸限制
 func (m CreateOrderRequest) Validate() error {
+	return m.ValidateWithLimits(nil)
+}
+
+// ValidateWithLimits 订单校验，并检查包裹是否超过 limits 中订单服务类型的重量和尺寸限制
+func (m CreateOrderRequest) ValidateWithLimits(limits ServiceLimits) error {
+	// 订单类型、服务类型、送货方式和合作方式的可选值由 entity 中对应类型的 Validate 方法校验
 	return validation.ValidateStruct(&m,
-		validation.Field(&m.OrderScope,
-			validation.Required.Error("订单类型不能为空"),
-			validation.In("DOMESTIC", "INTERNATIONAL").Error("无效的订单类型"),
-		),
-		validation.Field(&m.ServiceType,
-			validation.Required.Error("服务类型不能为空"),
-			validation.In("ECO", "EXP").Error("无效的服务类型"),
-		),
-		validation.Field(&m.DeliveryMethod,
-			validation.Required.Error("送货方式不能为空"),
-			validation.In("HDY", "SPU").Error("无效的送货方式"),
-		),
-		validation.Field(&m.CooperationMethod,
-			validation.Required.Error("合作方式不能为空"),
-			validation.In("PLATFORM", "MERCHANT", "WESTERN_POST").Error("无效的合作方式"),
-		),
+		validation.Field(&m.OrderScope, validation.Required.Error("订单类型不能为空")),
+		validation.Field(&m.ServiceType, validation.Required.Error("服务类型不能为空")),
+		validation.Field(&m.DeliveryMethod, validation.Required.Error("送货方式不能为空")),
+		validation.Field(&m.CooperationMethod, validation.Required.Error("合作方式不能为空")),
 		validation.Field(&m.SelfPickupCode,
-			validation.When(m.DeliveryMethod == "SPU", validation.Required.Error("自提码不能为空")),
+			validation.When(m.DeliveryMethod == entity.DeliveryMethodSpu, validation.Required.Error("自提码不能为空")),
 		),
 		validation.Field(&m.InsuranceService, validation.When(m.InsuranceService != nil, validation.By(func(value interface{}) error {
 			v, ok := value.(*InsuranceService)
@@ -251,7 +321,7 @@ func (m CreateOrderRequest) Validate() error {
 			if !ok {
 				return errors.New("无效的包裹数据")
 			}
-			return v.Validate()
+			return v.ValidateWithLimits(m.ServiceType, limits)
 		})),
 		validation.Field(&m.ShippingLabelInfo, validation.By(func(value interface{}) error {
 			v, ok := value.(ShippingLabelInformation)
@@ -271,23 +341,21 @@ type CreateOrderResult struct {
 }
 
 // Create 创建订单并获取面单 PDF 的 Base64 编码
-func (s orderService) Create(ctx context.Context, request CreateOrderRequest) (entity.Order, error) {
-	if err := request.Validate(); err != nil {
+func (s orderService) Create(ctx context.Context, request CreateOrderRequest, opts ...CallOption) (entity.Order, error) {
+	if err := request.ValidateWithLimits(s.limits); err != nil {
 		return entity.Order{}, invalidInput(err)
 	}
 
 	var res CreateOrderResult
-	resp, err := s.httpClient.R().
-		SetContext(ctx).
-		SetBody(request).
-		SetResult(&res).
-		Post("/createOrderAndGetLabelPdfBase64")
-	if err = recheckError(resp, err); err != nil {
+	err := service(s).handle(ctx, &Call{
+		Endpoint: EndpointCreateOrder,
+		Method:   http.MethodPost,
+		Body:     request,
+		Result:   &res,
+	}, opts)
+	if err != nil {
 		return entity.Order{}, err
 	}
-	if !res.Result.Success {
-		return entity.Order{}, errors.New(res.Result.Message)
-	}
 	return entity.Order{
 		CustomerOrderNumber: request.ShippingLabelInfo.OrderNumber,
 		ShipmentNumber:      res.TrackingNo,
@@ -297,80 +365,102 @@ func (s orderService) Create(ctx context.Context, request CreateOrderRequest) (e
 }
 
 // Cancel 取消订单，仅支持未揽收的订单
-func (s orderService) Cancel(ctx context.Context, shipmentNumber string) (bool, error) {
+func (s orderService) Cancel(ctx context.Context, shipmentNumber string, opts ...CallOption) (bool, error) {
 	var res response.Result
-	resp, err := s.httpClient.R().
-		SetContext(ctx).
-		SetBody(map[string]string{
+	err := service(s).handle(ctx, &Call{
+		Endpoint: EndpointCancelOrder,
+		Method:   http.MethodPost,
+		Body: map[string]string{
 			"trackingNo": shipmentNumber,
-		}).
-		SetResult(&res).
-		Post("/cancelOrder")
-	if err = recheckError(resp, err); err != nil {
+		},
+		Result: &res,
+	}, opts)
+	if err != nil {
 		return false, err
 	}
-	if !res.Success {
-		return false, errors.New(res.Message)
-	}
 	return true, nil
 }
 
 // Tracking 查询物流轨迹
+//
+// shipmentNumbers 为可变参数，不能直接传入 CallOption，需要时使用 ContextWithCallOptions：
+//
+//	ctx = swiftx.ContextWithCallOptions(ctx, swiftx.CallTimeout(30*time.Second))
+//	results, err := client.Services.Order.Tracking(ctx, "SWX1", "SWX2")
 func (s orderService) Tracking(ctx context.Context, shipmentNumbers ...string) ([]entity.TrackingResult, error) {
 	var results []entity.TrackingResult
-	resp, err := s.httpClient.R().
-		SetContext(ctx).
-		SetBody(map[string][]string{
+	err := service(s).handle(ctx, &Call{
+		Endpoint: EndpointBatchGetTracking,
+		Method:   http.MethodPost,
+		Body: map[string][]string{
 			"trackingNoList": shipmentNumbers,
-		}).
-		SetResult(&results).
-		Post("/batchGetTrackingInfo")
-	if err = recheckError(resp, err); err != nil {
+		},
+		Result: &results,
+	}, nil)
+	if err != nil {
 		return nil, err
 	}
 	return results, nil
 }
 
-// Postage 获取订单价格
-func (s orderService) Postage(ctx context.Context, shipmentNumbers ...string) ([]entity.OrderPrice, error) {
-	var results []struct {
-		response.Result `json:"result"`
-		TrackingNo      string `json:"trackingNo"`
-		ShippingCharge  struct {
-			Total struct {
-				Amount       float64 `json:"amount"`
-				CurrencyCode string  `json:"currencyCode"`
-			} `json:"total"`
-			PriceDetail []struct {
-				Cost struct {
-					Amount       float64 `json:"amount"`
-					CurrencyCode string  `json:"currencyCode"`
-				} `json:"cost"`
-				Description string `json:"description"`
-			} `json:"priceDetail"`
-		} `json:"shippingCharge"`
+// postageResult 批量查询订单价格的单个订单结果
+type postageResult struct {
+	response.Result `json:"result"`
+	TrackingNo      string `json:"trackingNo"`
+	ShippingCharge  struct {
+		Total       Value `json:"total"`
+		PriceDetail []struct {
+			Cost        Value  `json:"cost"`
+			Description string `json:"description"`
+		} `json:"priceDetail"`
+	} `json:"shippingCharge"`
+}
+
+// orderPrice 转换为订单价格
+func (r postageResult) orderPrice() entity.OrderPrice {
+	details := make([]entity.PriceDetail, len(r.ShippingCharge.PriceDetail))
+	for i, detail := range r.ShippingCharge.PriceDetail {
+		details[i] = entity.PriceDetail{
+			Cost:        detail.Cost.Money,
+			Description: detail.Description,
+		}
+	}
+	return entity.OrderPrice{
+		TrackingNumber: r.TrackingNo,
+		Amount:         r.ShippingCharge.Total.Money,
+		Details:        details,
 	}
-	resp, err := s.httpClient.R().
-		SetContext(ctx).
-		SetBody(map[string][]string{
+}
+
+// postage 批量查询订单价格，返回包含每个订单号业务结果的原始数据
+func (s orderService) postage(ctx context.Context, shipmentNumbers ...string) ([]postageResult, error) {
+	var results []postageResult
+	err := service(s).handle(ctx, &Call{
+		Endpoint: EndpointBatchGetPrice,
+		Method:   http.MethodPost,
+		Body: map[string][]string{
 			"trackingNoList": shipmentNumbers,
-		}).
-		SetResult(&results).
-		Post("/batchGetOrderPrice")
-	if err = recheckError(resp, err); err != nil {
+		},
+		Result: &results,
+	}, nil)
+	if err != nil {
+		return nil, err
+	}
+	return results, nil
+}
+
+// Postage 获取订单价格
+//
+// 与 Tracking 相同，CallOption 需要通过 ContextWithCallOptions 传入
+func (s orderService) Postage(ctx context.Context, shipmentNumbers ...string) ([]entity.OrderPrice, error) {
+	results, err := s.postage(ctx, shipmentNumbers...)
+	if err != nil {
 		return nil, err
 	}
 
 	prices := make([]entity.OrderPrice, 0)
 	for _, result := range results {
-		prices = append(prices, entity.OrderPrice{
-			TrackingNumber: result.TrackingNo,
-			Amount: entity.Money{
-				CurrencyCode: result.ShippingCharge.Total.CurrencyCode,
-				Value:        result.ShippingCharge.Total.Amount,
-			},
-			Details: nil,
-		})
+		prices = append(prices, result.orderPrice())
 	}
 	return prices, nil
 }
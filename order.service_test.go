@@ -1,11 +1,11 @@
 package swiftx
 
 import (
+	"encoding/json"
 	"testing"
 
 	"github.com/hiscaler/swiftx-go/entity"
 	"github.com/stretchr/testify/assert"
-	"gopkg.in/guregu/null.v4"
 )
 
 func TestOrderService_Create(t *testing.T) {
@@ -38,8 +38,7 @@ func TestOrderService_Create(t *testing.T) {
 		InsuranceService: &InsuranceService{
 			IsInsured: false,
 			InsuredValue: Value{
-				Amount:       100,
-				CurrencyCode: "USD",
+				Money: entity.MustParseMoney("100", entity.CurrencyUSD),
 			},
 		},
 		PackageInfo: CreateOrderPackageInformation{ // 包裹信息
@@ -51,8 +50,7 @@ func TestOrderService_Create(t *testing.T) {
 			Width:            10,               // 宽度
 			Height:           5,                // 高度
 			Value: Value{ // 费用金额
-				Amount:       100,
-				CurrencyCode: "USD",
+				Money: entity.MustParseMoney("100", entity.CurrencyUSD),
 			},
 			CustomerName: "测试客户", // 客户名
 			StoreName:    "测试店铺", // 店铺名
@@ -61,13 +59,13 @@ func TestOrderService_Create(t *testing.T) {
 					Name:     "测试 SKU 1",
 					Quantity: 1,
 					Code:     "SKU001",
-					Value:    null.FloatFrom(50),
+					Value:    entity.NullMoneyFrom(entity.MustParseMoney("50", entity.CurrencyUSD)),
 				},
 				{
 					Name:     "测试 SKU 2",
 					Quantity: 2,
 					Code:     "SKU002",
-					Value:    null.FloatFrom(25),
+					Value:    entity.NullMoneyFrom(entity.MustParseMoney("25", entity.CurrencyUSD)),
 				},
 			},
 		},
@@ -120,6 +118,41 @@ func TestOrderService_Postage(t *testing.T) {
 		t.Error("期望获取到订单价格，但结果为空")
 	} else {
 		assert.Equal(t, shipmentNumber, results[0].TrackingNumber)
-		assert.Greater(t, results[0].Amount.Value, 0.0)
+		assert.Greater(t, results[0].Amount.Amount, int64(0))
 	}
 }
+
+func TestValue_JSON(t *testing.T) {
+	info := CreateOrderPackageInformation{
+		Value: Value{Money: entity.MustParseMoney("100", entity.CurrencyUSD)},
+		SkuList: []CreateOrderPackageGoods{
+			{Name: "SKU 1", Quantity: 3, Value: entity.NullMoneyFrom(entity.MustParseMoney("0.1", entity.CurrencyUSD))},
+			{Name: "SKU 2", Quantity: 1, Value: entity.NullMoneyFrom(entity.MustParseMoney("0.2", ""))},
+			{Name: "SKU 3", Quantity: 1},
+		},
+	}
+	b, err := json.Marshal(struct {
+		Value   Value                     `json:"value"`
+		SkuList []CreateOrderPackageGoods `json:"skuList"`
+	}{info.Value, info.SkuList})
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"value":{"amount":100,"currencyCode":"USD"},"skuList":[{"name":"SKU 1","quantity":3,"value":0.1,"currencyCode":"USD"},{"name":"SKU 2","quantity":1,"value":0.2},{"name":"SKU 3","quantity":1}]}`, string(b))
+
+	var skus []CreateOrderPackageGoods
+	assert.NoError(t, json.Unmarshal([]byte(`[{"name":"SKU 1","quantity":3,"value":0.1,"currencyCode":"USD"},{"name":"SKU 3","quantity":1}]`), &skus))
+	assert.Equal(t, []CreateOrderPackageGoods{info.SkuList[0], info.SkuList[2]}, skus)
+	var v Value
+	assert.NoError(t, json.Unmarshal([]byte(`{"amount":12.345,"currencyCode":"CNY"}`), &v))
+	assert.Equal(t, entity.NewMoney(1235, entity.CurrencyCNY), v.Money)
+
+	total, err := info.SkuValueTotal()
+	assert.NoError(t, err)
+	assert.Equal(t, "0.50 USD", total.String())
+	info.SkuList[1].Value.Currency = entity.CurrencyCNY
+	_, err = info.SkuValueTotal()
+	assert.ErrorIs(t, err, entity.ErrCurrencyMismatch)
+
+	assert.Error(t, Value{Money: entity.NewMoney(100, "EUR")}.Validate())
+	assert.Error(t, Value{Money: entity.NewMoney(-1, entity.CurrencyUSD)}.Validate())
+	assert.NoError(t, info.Value.Validate())
+}
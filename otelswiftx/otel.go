@@ -0,0 +1,230 @@
+// Package otelswiftx 为 SwiftX 客户端提供 OpenTelemetry 追踪和指标
+//
+//	inst, err := otelswiftx.New(otelswiftx.Config{})
+//	client := swiftx.NewClient(cfg,
+//		swiftx.WithMiddleware(inst.Middleware()),
+//		swiftx.WithRateLimiter(inst.RateLimiter(swiftx.NewRateLimiter(10, 1))),
+//	)
+//
+// 每次 API 调用创建一个 Span，Span 的父级来自服务方法的 ctx 参数，追踪上下文通过请求头传递给服务端。
+package otelswiftx
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/hiscaler/swiftx-go"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// ScopeName 追踪和指标的 Instrumentation Scope 名称
+const ScopeName = "github.com/hiscaler/swiftx-go/otelswiftx"
+
+// 属性名称
+const (
+	AttrEndpoint            = attribute.Key("swiftx.endpoint")              // 接口名称
+	AttrSuccess             = attribute.Key("swiftx.success")               // 业务是否成功
+	AttrRetryCount          = attribute.Key("swiftx.retry_count")           // 重试次数
+	AttrTrackingNumberCount = attribute.Key("swiftx.tracking_number.count") // 请求中的订单号数量
+	AttrErrorType           = attribute.Key("error.type")                   // 错误分类
+	AttrHTTPMethod          = attribute.Key("http.request.method")          // HTTP 方法
+	AttrHTTPStatusCode      = attribute.Key("http.response.status_code")    // HTTP 状态码
+	AttrDryRun              = attribute.Key("swiftx.dry_run")               // 是否为试运行（没有发送到 SwiftX）
+)
+
+// 指标名称
+const (
+	MetricRequestDuration   = "swiftx.client.request.duration"    // 请求耗时（秒，包括限流等待和重试）
+	MetricRequestErrors     = "swiftx.client.request.errors"      // 按分类统计的错误数
+	MetricRateLimitWaits    = "swiftx.client.rate_limit.waits"    // 实际等待的限流次数
+	MetricRateLimitDuration = "swiftx.client.rate_limit.duration" // 限流等待耗时（秒）
+	MetricRateLimitFailures = "swiftx.client.rate_limit.failures" // 限流等待失败（ctx 取消或超时）次数
+)
+
+// rateLimitBlockedThreshold 限流等待超过该时间才认为被限流，立即获得令牌的调用不计入等待次数
+const rateLimitBlockedThreshold = time.Millisecond
+
+// Config 配置
+type Config struct {
+	TracerProvider trace.TracerProvider          // 为空时使用 otel.GetTracerProvider()
+	MeterProvider  metric.MeterProvider          // 为空时使用 otel.GetMeterProvider()
+	Propagator     propagation.TextMapPropagator // 为空时使用 otel.GetTextMapPropagator()
+}
+
+// Instrumentation OpenTelemetry 追踪和指标
+type Instrumentation struct {
+	tracer            trace.Tracer
+	propagator        propagation.TextMapPropagator
+	requestDuration   metric.Float64Histogram
+	requestErrors     metric.Int64Counter
+	rateLimitWaits    metric.Int64Counter
+	rateLimitDuration metric.Float64Histogram
+	rateLimitFailures metric.Int64Counter
+}
+
+// New 创建 Instrumentation
+func New(cfg Config) (*Instrumentation, error) {
+	tp := cfg.TracerProvider
+	if tp == nil {
+		tp = otel.GetTracerProvider()
+	}
+	mp := cfg.MeterProvider
+	if mp == nil {
+		mp = otel.GetMeterProvider()
+	}
+	propagator := cfg.Propagator
+	if propagator == nil {
+		propagator = otel.GetTextMapPropagator()
+	}
+
+	inst := &Instrumentation{
+		tracer:     tp.Tracer(ScopeName),
+		propagator: propagator,
+	}
+	meter := mp.Meter(ScopeName)
+	var err error
+	if inst.requestDuration, err = meter.Float64Histogram(MetricRequestDuration,
+		metric.WithUnit("s"),
+		metric.WithDescription("SwiftX API 请求耗时"),
+	); err != nil {
+		return nil, fmt.Errorf("otelswiftx: %w", err)
+	}
+	if inst.requestErrors, err = meter.Int64Counter(MetricRequestErrors,
+		metric.WithUnit("{error}"),
+		metric.WithDescription("SwiftX API 请求错误数"),
+	); err != nil {
+		return nil, fmt.Errorf("otelswiftx: %w", err)
+	}
+	if inst.rateLimitWaits, err = meter.Int64Counter(MetricRateLimitWaits,
+		metric.WithUnit("{wait}"),
+		metric.WithDescription("SwiftX API 请求限流等待次数"),
+	); err != nil {
+		return nil, fmt.Errorf("otelswiftx: %w", err)
+	}
+	if inst.rateLimitDuration, err = meter.Float64Histogram(MetricRateLimitDuration,
+		metric.WithUnit("s"),
+		metric.WithDescription("SwiftX API 请求限流等待耗时"),
+	); err != nil {
+		return nil, fmt.Errorf("otelswiftx: %w", err)
+	}
+	if inst.rateLimitFailures, err = meter.Int64Counter(MetricRateLimitFailures,
+		metric.WithUnit("{error}"),
+		metric.WithDescription("SwiftX API 请求限流等待失败次数"),
+	); err != nil {
+		return nil, fmt.Errorf("otelswiftx: %w", err)
+	}
+	return inst, nil
+}
+
+// Middleware 返回为每次 API 调用创建 Span 并记录指标的中间件
+func (i *Instrumentation) Middleware() swiftx.Middleware {
+	return func(next swiftx.Handler) swiftx.Handler {
+		return swiftx.HandlerFunc(func(ctx context.Context, call *swiftx.Call) error {
+			attrs := []attribute.KeyValue{
+				AttrEndpoint.String(call.Endpoint),
+				AttrHTTPMethod.String(call.Method),
+			}
+			if call.Endpoint == swiftx.EndpointBatchGetTracking || call.Endpoint == swiftx.EndpointBatchGetPrice {
+				attrs = append(attrs, AttrTrackingNumberCount.Int(len(call.TrackingNumbers())))
+			}
+			if call.DryRun {
+				attrs = append(attrs, AttrDryRun.Bool(true))
+			}
+			ctx, span := i.tracer.Start(ctx, "SwiftX "+call.Endpoint,
+				trace.WithSpanKind(trace.SpanKindClient),
+				trace.WithAttributes(attrs...),
+			)
+			defer span.End()
+
+			if call.Header == nil {
+				call.Header = make(map[string][]string)
+			}
+			i.propagator.Inject(ctx, propagation.HeaderCarrier(call.Header))
+
+			err := next.Handle(ctx, call)
+
+			category := call.ErrorCategory(err)
+			if call.StatusCode != 0 {
+				span.SetAttributes(AttrHTTPStatusCode.Int(call.StatusCode))
+			}
+			if call.Attempts > 0 {
+				span.SetAttributes(AttrRetryCount.Int(call.Attempts - 1))
+			}
+			var resultErr *swiftx.ResultError
+			if err == nil || errors.As(err, &resultErr) {
+				span.SetAttributes(AttrSuccess.Bool(err == nil))
+			}
+			if err != nil {
+				span.SetAttributes(AttrErrorType.String(category))
+				span.RecordError(err)
+				span.SetStatus(codes.Error, err.Error())
+			}
+
+			if call.DryRun {
+				// 试运行的调用不会发送到 SwiftX，只记录 Span，不计入指标
+				return err
+			}
+			metricAttrs := []attribute.KeyValue{AttrEndpoint.String(call.Endpoint)}
+			if call.StatusCode != 0 {
+				metricAttrs = append(metricAttrs, AttrHTTPStatusCode.Int(call.StatusCode))
+			}
+			if err != nil {
+				metricAttrs = append(metricAttrs, AttrErrorType.String(category))
+				i.requestErrors.Add(ctx, 1, metric.WithAttributes(
+					AttrEndpoint.String(call.Endpoint),
+					AttrErrorType.String(category),
+				))
+			}
+			i.requestDuration.Record(ctx, call.Latency.Seconds(), metric.WithAttributes(metricAttrs...))
+			return err
+		})
+	}
+}
+
+// RateLimiter 包装限流器，记录实际等待的限流次数和耗时，并在当前 Span 中添加 rate_limit.wait 事件，
+// 等待失败（ctx 取消或超时）单独记录为 MetricRateLimitFailures
+func (i *Instrumentation) RateLimiter(limiter swiftx.RateLimiter) swiftx.RateLimiter {
+	return &rateLimiter{inst: i, next: limiter}
+}
+
+type rateLimiter struct {
+	inst *Instrumentation
+	next swiftx.RateLimiter
+}
+
+func (l *rateLimiter) Wait(ctx context.Context) error {
+	start := time.Now()
+	err := l.next.Wait(ctx)
+	wait := time.Since(start)
+
+	span := trace.SpanFromContext(ctx)
+	if err != nil {
+		// rate.Limiter 在 ctx 的截止时间之前无法获得令牌时直接返回错误，也按超时统计
+		category := swiftx.ErrorCategoryTimeout
+		if errors.Is(err, context.Canceled) {
+			category = swiftx.ErrorCategoryCanceled
+		}
+		l.inst.rateLimitFailures.Add(ctx, 1, metric.WithAttributes(AttrErrorType.String(category)))
+		span.AddEvent("rate_limit.error", trace.WithAttributes(
+			AttrErrorType.String(category),
+			attribute.Float64("rate_limit.wait.duration", wait.Seconds()),
+		))
+		return err
+	}
+	if wait < rateLimitBlockedThreshold {
+		return nil
+	}
+	l.inst.rateLimitWaits.Add(ctx, 1)
+	l.inst.rateLimitDuration.Record(ctx, wait.Seconds())
+	span.AddEvent("rate_limit.wait", trace.WithAttributes(
+		attribute.Float64("rate_limit.wait.duration", wait.Seconds()),
+	))
+	return nil
+}
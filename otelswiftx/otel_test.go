@@ -0,0 +1,235 @@
+package otelswiftx
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/hiscaler/swiftx-go"
+	"github.com/hiscaler/swiftx-go/config"
+	"github.com/hiscaler/swiftx-go/entity"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+// redirectTransport 将请求转发到测试服务器
+type redirectTransport struct {
+	target *url.URL
+}
+
+func (t redirectTransport) RoundTrip(r *http.Request) (*http.Response, error) {
+	r = r.Clone(r.Context())
+	r.URL.Scheme = t.target.Scheme
+	r.URL.Host = t.target.Host
+	return http.DefaultTransport.RoundTrip(r)
+}
+
+type noopLimiter struct{}
+
+func (noopLimiter) Wait(context.Context) error { return nil }
+
+func attrs(kvs []attribute.KeyValue) map[attribute.Key]attribute.Value {
+	m := make(map[attribute.Key]attribute.Value, len(kvs))
+	for _, kv := range kvs {
+		m[kv.Key] = kv.Value
+	}
+	return m
+}
+
+func TestInstrumentation(t *testing.T) {
+	traceparents := make(map[string]string)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		traceparents[r.URL.Path] = r.Header.Get("Traceparent")
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/api/v2/openapi/" + swiftx.EndpointBatchGetTracking:
+			fmt.Fprint(w, `[{"trackingNo":"SWX1","success":true},{"trackingNo":"SWX2","success":true}]`)
+		case "/api/v2/openapi/" + swiftx.EndpointCancelOrder:
+			fmt.Fprint(w, `{"success":false,"message":"订单已揽收"}`)
+		default:
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	}))
+	defer srv.Close()
+	target, _ := url.Parse(srv.URL)
+
+	spans := tracetest.NewSpanRecorder()
+	reader := sdkmetric.NewManualReader()
+	inst, err := New(Config{
+		TracerProvider: sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(spans)),
+		MeterProvider:  sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader)),
+		Propagator:     propagation.TraceContext{},
+	})
+	require.NoError(t, err)
+
+	client := swiftx.NewClient(config.Config{
+		Env:       entity.Test,
+		Timeout:   5,
+		AppKey:    "test-app-key",
+		AppSecret: "test-app-secret",
+	},
+		swiftx.WithTransport(redirectTransport{target: target}),
+		swiftx.WithMiddleware(inst.Middleware()),
+		swiftx.WithRateLimiter(inst.RateLimiter(noopLimiter{})),
+	)
+
+	ctx := context.Background()
+	_, err = client.Services.Order.Tracking(ctx, "SWX1", "SWX2")
+	assert.NoError(t, err)
+	_, err = client.Services.Order.Cancel(ctx, "SWX1")
+	assert.Error(t, err)
+
+	ended := spans.Ended()
+	require.Len(t, ended, 2)
+
+	tracking := ended[0]
+	assert.Equal(t, "SwiftX "+swiftx.EndpointBatchGetTracking, tracking.Name())
+	assert.Contains(t, traceparents["/api/v2/openapi/"+swiftx.EndpointBatchGetTracking], tracking.SpanContext().TraceID().String())
+	a := attrs(tracking.Attributes())
+	assert.Equal(t, int64(2), a[AttrTrackingNumberCount].AsInt64())
+	assert.Equal(t, int64(http.StatusOK), a[AttrHTTPStatusCode].AsInt64())
+	assert.Equal(t, int64(0), a[AttrRetryCount].AsInt64())
+	assert.True(t, a[AttrSuccess].AsBool())
+	// 立即获得令牌，不记录限流等待
+	assert.Empty(t, tracking.Events())
+
+	cancel := ended[1]
+	a = attrs(cancel.Attributes())
+	assert.False(t, a[AttrSuccess].AsBool())
+	assert.Equal(t, swiftx.ErrorCategoryBusiness, a[AttrErrorType].AsString())
+	_, ok := a[AttrTrackingNumberCount]
+	assert.False(t, ok)
+	assert.Equal(t, codes.Error, cancel.Status().Code)
+
+	var rm metricdata.ResourceMetrics
+	require.NoError(t, reader.Collect(ctx, &rm))
+	metrics := make(map[string]metricdata.Aggregation)
+	for _, sm := range rm.ScopeMetrics {
+		for _, m := range sm.Metrics {
+			metrics[m.Name] = m.Data
+		}
+	}
+	if hist, ok := metrics[MetricRequestDuration].(metricdata.Histogram[float64]); assert.True(t, ok) {
+		assert.Len(t, hist.DataPoints, 2)
+	}
+	if sum, ok := metrics[MetricRequestErrors].(metricdata.Sum[int64]); assert.True(t, ok) && assert.Len(t, sum.DataPoints, 1) {
+		assert.Equal(t, int64(1), sum.DataPoints[0].Value)
+		category, _ := sum.DataPoints[0].Attributes.Value(AttrErrorType)
+		assert.Equal(t, swiftx.ErrorCategoryBusiness, category.AsString())
+	}
+	_, ok = metrics[MetricRateLimitWaits]
+	assert.False(t, ok)
+}
+
+// sleepLimiter 每次等待 d 后返回，ctx 结束时返回 ctx 的错误
+type sleepLimiter struct {
+	d time.Duration
+}
+
+func (l sleepLimiter) Wait(ctx context.Context) error {
+	select {
+	case <-time.After(l.d):
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func TestInstrumentation_RateLimiter(t *testing.T) {
+	spans := tracetest.NewSpanRecorder()
+	reader := sdkmetric.NewManualReader()
+	inst, err := New(Config{
+		TracerProvider: sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(spans)),
+		MeterProvider:  sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader)),
+	})
+	require.NoError(t, err)
+
+	ctx, span := inst.tracer.Start(context.Background(), "test")
+	assert.NoError(t, inst.RateLimiter(noopLimiter{}).Wait(ctx))
+	assert.NoError(t, inst.RateLimiter(sleepLimiter{d: 10 * time.Millisecond}).Wait(ctx))
+	canceled, cancel := context.WithCancel(ctx)
+	cancel()
+	assert.ErrorIs(t, inst.RateLimiter(sleepLimiter{d: time.Second}).Wait(canceled), context.Canceled)
+	timeout, cancel := context.WithTimeout(ctx, 10*time.Millisecond)
+	defer cancel()
+	assert.ErrorIs(t, inst.RateLimiter(sleepLimiter{d: time.Second}).Wait(timeout), context.DeadlineExceeded)
+	span.End()
+
+	ended := spans.Ended()
+	require.Len(t, ended, 1)
+	events := ended[0].Events()
+	if assert.Len(t, events, 3) {
+		assert.Equal(t, "rate_limit.wait", events[0].Name)
+		assert.Equal(t, "rate_limit.error", events[1].Name)
+		assert.Equal(t, "rate_limit.error", events[2].Name)
+	}
+
+	var rm metricdata.ResourceMetrics
+	require.NoError(t, reader.Collect(ctx, &rm))
+	metrics := make(map[string]metricdata.Aggregation)
+	for _, sm := range rm.ScopeMetrics {
+		for _, m := range sm.Metrics {
+			metrics[m.Name] = m.Data
+		}
+	}
+	if sum, ok := metrics[MetricRateLimitWaits].(metricdata.Sum[int64]); assert.True(t, ok) && assert.Len(t, sum.DataPoints, 1) {
+		assert.Equal(t, int64(1), sum.DataPoints[0].Value)
+	}
+	if hist, ok := metrics[MetricRateLimitDuration].(metricdata.Histogram[float64]); assert.True(t, ok) && assert.Len(t, hist.DataPoints, 1) {
+		assert.Equal(t, uint64(1), hist.DataPoints[0].Count)
+	}
+	if sum, ok := metrics[MetricRateLimitFailures].(metricdata.Sum[int64]); assert.True(t, ok) {
+		failures := make(map[string]int64)
+		for _, dp := range sum.DataPoints {
+			category, _ := dp.Attributes.Value(AttrErrorType)
+			failures[category.AsString()] = dp.Value
+		}
+		assert.Equal(t, map[string]int64{swiftx.ErrorCategoryCanceled: 1, swiftx.ErrorCategoryTimeout: 1}, failures)
+	}
+}
+
+func TestInstrumentation_DryRun(t *testing.T) {
+	spans := tracetest.NewSpanRecorder()
+	reader := sdkmetric.NewManualReader()
+	inst, err := New(Config{
+		TracerProvider: sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(spans)),
+		MeterProvider:  sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader)),
+	})
+	require.NoError(t, err)
+
+	client := swiftx.NewClient(config.Config{
+		Env:       entity.Test,
+		Timeout:   5,
+		AppKey:    "test-app-key",
+		AppSecret: "test-app-secret",
+	}, swiftx.WithDryRun(true), swiftx.WithMiddleware(inst.Middleware()))
+
+	ctx := context.Background()
+	_, err = client.Services.Order.Cancel(ctx, "SWX1")
+	assert.NoError(t, err)
+
+	ended := spans.Ended()
+	if assert.Len(t, ended, 1) {
+		assert.True(t, attrs(ended[0].Attributes())[AttrDryRun].AsBool())
+	}
+	// 试运行的调用不计入指标
+	var rm metricdata.ResourceMetrics
+	require.NoError(t, reader.Collect(ctx, &rm))
+	for _, sm := range rm.ScopeMetrics {
+		for _, m := range sm.Metrics {
+			assert.NotEqual(t, MetricRequestDuration, m.Name)
+			assert.NotEqual(t, MetricRequestErrors, m.Name)
+		}
+	}
+}
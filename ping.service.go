@@ -2,6 +2,8 @@ package swiftx
 
 import (
 	"context"
+	"net/http"
+	"net/url"
 	"strconv"
 )
 
@@ -9,15 +11,22 @@ import (
 type pingService service
 
 // Pong 返回请求的数值，可用于请求测试/健康检查
-func (s pingService) Pong(ctx context.Context, i int) (int, error) {
+func (s pingService) Pong(ctx context.Context, i int, opts ...CallOption) (int, error) {
+	res, _, err := s.pong(ctx, i, opts...)
+	return res, err
+}
+
+// pong 返回请求的数值和调用信息
+func (s pingService) pong(ctx context.Context, i int, opts ...CallOption) (int, *Call, error) {
 	var res int
-	resp, err := s.httpClient.R().
-		SetContext(ctx).
-		SetQueryParam("i", strconv.Itoa(i)).
-		SetResult(&res).
-		Get("/pingPong")
-	if err = recheckError(resp, err); err != nil {
-		return 0, err
+	call := &Call{
+		Endpoint: EndpointPingPong,
+		Method:   http.MethodGet,
+		Query:    url.Values{"i": []string{strconv.Itoa(i)}},
+		Result:   &res,
+	}
+	if err := service(s).handle(ctx, call, opts); err != nil {
+		return 0, call, err
 	}
-	return res, nil
+	return res, call, nil
 }
@@ -0,0 +1,331 @@
+package printer
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"net"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/hiscaler/swiftx-go/entity"
+)
+
+// DefaultPort RAW 打印端口（JetDirect）
+const DefaultPort = "9100"
+
+var (
+	ErrPrinterClosed = errors.New("printer: 打印机已关闭")
+	ErrQueueFull     = errors.New("printer: 打印队列已满")
+	ErrEmptyDocument = errors.New("printer: 打印内容不能为空")
+)
+
+// 打印机状态
+const (
+	StateIdle     = "idle"     // 空闲
+	StatePrinting = "printing" // 打印中
+	StateOffline  = "offline"  // 离线（最近一次打印失败）
+	StateClosed   = "closed"   // 已关闭
+)
+
+// Config 打印机配置
+type Config struct {
+	Name          string        // 打印机名称，比如打包台编号
+	Address       string        // 打印机地址，比如 192.168.1.100:9100，未指定端口时使用 9100
+	DialTimeout   time.Duration // 连接超时（默认 5 秒）
+	WriteTimeout  time.Duration // 写入超时（默认 30 秒）
+	Retries       int           // 失败后的重试次数
+	RetryWaitTime time.Duration // 重试间隔（默认 1 秒）
+	QueueSize     int           // 打印队列长度（默认 100）
+}
+
+// Status 打印机状态
+type Status struct {
+	Name          string    // 打印机名称
+	Address       string    // 打印机地址
+	State         string    // 当前状态
+	Queued        int       // 排队中的任务数
+	Printed       int64     // 已成功打印的任务数
+	Failed        int64     // 打印失败的任务数
+	LastError     error     // 最近一次错误
+	LastPrintedAt time.Time // 最近一次成功打印时间
+}
+
+// Job 打印任务
+type Job struct {
+	ID       string // 任务编号
+	Name     string // 任务名称，比如 SwiftX 订单号
+	Data     []byte // 打印内容
+	ctx      context.Context
+	done     chan struct{}
+	attempts int // 实际尝试次数
+	err      error
+}
+
+// Done 任务完成（成功或失败）时关闭
+func (j *Job) Done() <-chan struct{} {
+	return j.done
+}
+
+// Err 任务完成后的错误信息
+func (j *Job) Err() error {
+	select {
+	case <-j.done:
+		return j.err
+	default:
+		return nil
+	}
+}
+
+// Attempts 任务完成后的实际尝试次数
+func (j *Job) Attempts() int {
+	select {
+	case <-j.done:
+		return j.attempts
+	default:
+		return 0
+	}
+}
+
+// Wait 等待任务完成
+func (j *Job) Wait(ctx context.Context) error {
+	select {
+	case <-j.done:
+		return j.err
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Printer RAW 网络打印机
+type Printer struct {
+	config Config
+	dial   func(ctx context.Context, network, address string) (net.Conn, error)
+	jobs   chan *Job
+	seq    atomic.Int64
+	mu     sync.RWMutex
+	status Status
+	closed bool
+	wg     sync.WaitGroup
+}
+
+// New 创建打印机并启动打印队列
+func New(cfg Config) *Printer {
+	if _, _, err := net.SplitHostPort(cfg.Address); err != nil {
+		cfg.Address = net.JoinHostPort(strings.Trim(cfg.Address, "[]"), DefaultPort)
+	}
+	if cfg.Name == "" {
+		cfg.Name = cfg.Address
+	}
+	if cfg.DialTimeout <= 0 {
+		cfg.DialTimeout = 5 * time.Second
+	}
+	if cfg.WriteTimeout <= 0 {
+		cfg.WriteTimeout = 30 * time.Second
+	}
+	if cfg.Retries < 0 {
+		cfg.Retries = 0
+	}
+	if cfg.RetryWaitTime <= 0 {
+		cfg.RetryWaitTime = time.Second
+	}
+	if cfg.QueueSize <= 0 {
+		cfg.QueueSize = 100
+	}
+
+	dialer := &net.Dialer{Timeout: cfg.DialTimeout}
+	p := &Printer{
+		config: cfg,
+		dial:   dialer.DialContext,
+		jobs:   make(chan *Job, cfg.QueueSize),
+		status: Status{
+			Name:    cfg.Name,
+			Address: cfg.Address,
+			State:   StateIdle,
+		},
+	}
+	p.wg.Add(1)
+	go p.run()
+	return p
+}
+
+// Name 打印机名称
+func (p *Printer) Name() string {
+	return p.config.Name
+}
+
+// Status 返回打印机当前状态
+func (p *Printer) Status() Status {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	s := p.status
+	s.Queued = len(p.jobs)
+	return s
+}
+
+// Submit 将打印内容加入打印队列，队列已满时返回 ErrQueueFull
+func (p *Printer) Submit(ctx context.Context, name string, data []byte) (*Job, error) {
+	if len(data) == 0 {
+		return nil, ErrEmptyDocument
+	}
+
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	if p.closed {
+		return nil, ErrPrinterClosed
+	}
+
+	job := &Job{
+		ID:   fmt.Sprintf("%s-%d", p.config.Name, p.seq.Add(1)),
+		Name: name,
+		Data: data,
+		ctx:  ctx,
+		done: make(chan struct{}),
+	}
+	select {
+	case p.jobs <- job:
+		return job, nil
+	default:
+		return nil, ErrQueueFull
+	}
+}
+
+// Print 打印内容并等待打印完成
+func (p *Printer) Print(ctx context.Context, name string, data []byte) error {
+	job, err := p.Submit(ctx, name, data)
+	if err != nil {
+		return err
+	}
+	return job.Wait(ctx)
+}
+
+// SubmitLabel 解码订单面单（Base64）并加入打印队列
+func (p *Printer) SubmitLabel(ctx context.Context, order entity.Order) (*Job, error) {
+	data, err := DecodeLabel(order)
+	if err != nil {
+		return nil, err
+	}
+	return p.Submit(ctx, order.ShipmentNumber, data)
+}
+
+// PrintLabel 打印订单面单并等待打印完成
+func (p *Printer) PrintLabel(ctx context.Context, order entity.Order) error {
+	job, err := p.SubmitLabel(ctx, order)
+	if err != nil {
+		return err
+	}
+	return job.Wait(ctx)
+}
+
+// Close 停止接收新任务，等待队列中的任务处理完毕
+func (p *Printer) Close() error {
+	p.mu.Lock()
+	if p.closed {
+		p.mu.Unlock()
+		return nil
+	}
+	p.closed = true
+	close(p.jobs)
+	p.mu.Unlock()
+
+	p.wg.Wait()
+	p.mu.Lock()
+	p.status.State = StateClosed
+	p.mu.Unlock()
+	return nil
+}
+
+func (p *Printer) run() {
+	defer p.wg.Done()
+	for job := range p.jobs {
+		p.mu.Lock()
+		p.status.State = StatePrinting
+		p.mu.Unlock()
+		job.err = p.process(job)
+		p.mu.Lock()
+		if job.err == nil {
+			p.status.State = StateIdle
+			p.status.Printed++
+			p.status.LastError = nil
+			p.status.LastPrintedAt = time.Now()
+		} else {
+			p.status.Failed++
+			p.status.LastError = job.err
+			if errors.Is(job.err, context.Canceled) || errors.Is(job.err, context.DeadlineExceeded) {
+				p.status.State = StateIdle
+			} else {
+				p.status.State = StateOffline
+			}
+		}
+		p.mu.Unlock()
+		close(job.done)
+	}
+}
+
+// process 发送打印任务，失败时按配置重试
+func (p *Printer) process(job *Job) error {
+	ctx := job.ctx
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	var err error
+	for attempt := 0; attempt <= p.config.Retries; attempt++ {
+		if attempt > 0 {
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-time.After(p.config.RetryWaitTime):
+			}
+		}
+		if e := ctx.Err(); e != nil {
+			return e
+		}
+		job.attempts++
+		if err = p.send(ctx, job.Data); err == nil {
+			return nil
+		}
+	}
+	return fmt.Errorf("printer: %s 打印失败（已尝试 %d 次）: %w", p.config.Name, job.attempts, err)
+}
+
+func (p *Printer) send(ctx context.Context, data []byte) error {
+	conn, err := p.dial(ctx, "tcp", p.config.Address)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	if err = conn.SetWriteDeadline(time.Now().Add(p.config.WriteTimeout)); err != nil {
+		return err
+	}
+	if _, err = conn.Write(data); err != nil {
+		return err
+	}
+	if tc, ok := conn.(*net.TCPConn); ok {
+		// 半关闭连接，通知打印机数据已发送完毕
+		return tc.CloseWrite()
+	}
+	return nil
+}
+
+// DecodeLabel 解码订单中的面单 Base64 内容
+func DecodeLabel(order entity.Order) ([]byte, error) {
+	s := strings.TrimSpace(order.ShippingLabel)
+	if s == "" {
+		return nil, ErrEmptyDocument
+	}
+	// 兼容 data URI 格式，比如 data:application/pdf;base64,JVBERi0...
+	if strings.HasPrefix(s, "data:") {
+		if i := strings.Index(s, ","); i >= 0 {
+			s = s[i+1:]
+		}
+	}
+	data, err := base64.StdEncoding.DecodeString(s)
+	if err != nil {
+		return nil, fmt.Errorf("printer: 面单 Base64 解码失败: %w", err)
+	}
+	return data, nil
+}
@@ -0,0 +1,134 @@
+package printer
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"io"
+	"net"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/hiscaler/swiftx-go/entity"
+	"github.com/stretchr/testify/assert"
+)
+
+// rawListener 模拟 RAW 9100 打印机，记录收到的每个打印任务
+type rawListener struct {
+	ln       net.Listener
+	mu       sync.Mutex
+	received [][]byte
+}
+
+func newRawListener(t *testing.T) *rawListener {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("net.Listen() error: %v", err)
+	}
+	l := &rawListener{ln: ln}
+	go func() {
+		for {
+			conn, err := ln.Accept()
+			if err != nil {
+				return
+			}
+			b, _ := io.ReadAll(conn)
+			conn.Close()
+			l.mu.Lock()
+			l.received = append(l.received, b)
+			l.mu.Unlock()
+		}
+	}()
+	t.Cleanup(func() { ln.Close() })
+	return l
+}
+
+func (l *rawListener) jobs() [][]byte {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return append([][]byte(nil), l.received...)
+}
+
+func TestPrinter_PrintLabel(t *testing.T) {
+	l := newRawListener(t)
+	p := New(Config{Name: "station-1", Address: l.ln.Addr().String()})
+	defer p.Close()
+
+	pdf := []byte("%PDF-1.4 test label")
+	order := entity.Order{
+		ShipmentNumber: "SWX000000000000000001",
+		ShippingLabel:  base64.StdEncoding.EncodeToString(pdf),
+	}
+	err := p.PrintLabel(context.Background(), order)
+	assert.NoError(t, err)
+	assert.Eventually(t, func() bool { return len(l.jobs()) == 1 }, time.Second, 10*time.Millisecond)
+	assert.Equal(t, pdf, l.jobs()[0])
+
+	status := p.Status()
+	assert.Equal(t, "station-1", status.Name)
+	assert.Equal(t, StateIdle, status.State)
+	assert.Equal(t, int64(1), status.Printed)
+	assert.Nil(t, status.LastError)
+}
+
+func TestPrinter_Queue(t *testing.T) {
+	l := newRawListener(t)
+	p := New(Config{Address: l.ln.Addr().String()})
+
+	jobs := make([]*Job, 0)
+	for _, s := range []string{"a", "b", "c"} {
+		job, err := p.Submit(context.Background(), s, []byte(s))
+		assert.NoError(t, err)
+		jobs = append(jobs, job)
+	}
+	assert.NoError(t, p.Close())
+	for _, job := range jobs {
+		assert.NoError(t, job.Err())
+	}
+	assert.Eventually(t, func() bool { return len(l.jobs()) == 3 }, time.Second, 10*time.Millisecond)
+	assert.Equal(t, StateClosed, p.Status().State)
+
+	_, err := p.Submit(context.Background(), "d", []byte("d"))
+	assert.True(t, errors.Is(err, ErrPrinterClosed))
+}
+
+func TestPrinter_Retry(t *testing.T) {
+	// 获取一个未监听的端口
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("net.Listen() error: %v", err)
+	}
+	address := ln.Addr().String()
+	ln.Close()
+
+	p := New(Config{
+		Address:       address,
+		DialTimeout:   100 * time.Millisecond,
+		Retries:       2,
+		RetryWaitTime: 10 * time.Millisecond,
+	})
+	defer p.Close()
+
+	job, err := p.Submit(context.Background(), "label", []byte("data"))
+	assert.NoError(t, err)
+	assert.Error(t, job.Wait(context.Background()))
+	assert.Equal(t, 3, job.Attempts())
+
+	status := p.Status()
+	assert.Equal(t, StateOffline, status.State)
+	assert.Equal(t, int64(1), status.Failed)
+	assert.Error(t, status.LastError)
+}
+
+func TestDecodeLabel(t *testing.T) {
+	_, err := DecodeLabel(entity.Order{})
+	assert.True(t, errors.Is(err, ErrEmptyDocument))
+
+	_, err = DecodeLabel(entity.Order{ShippingLabel: "not base64!"})
+	assert.Error(t, err)
+
+	b, err := DecodeLabel(entity.Order{ShippingLabel: "data:application/pdf;base64," + base64.StdEncoding.EncodeToString([]byte("pdf"))})
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("pdf"), b)
+}
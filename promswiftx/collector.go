@@ -0,0 +1,170 @@
+// Package promswiftx 为 SwiftX 客户端提供 Prometheus 指标
+//
+//	collector := promswiftx.NewCollector(promswiftx.CollectorOpts{})
+//	prometheus.MustRegister(collector)
+//	client := swiftx.NewClient(cfg, collector.Option())
+//
+// 多个 Client 可以共用同一个 Collector。
+package promswiftx
+
+import (
+	"context"
+	"errors"
+	"strconv"
+
+	"github.com/hiscaler/swiftx-go"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// CollectorOpts 指标配置
+type CollectorOpts struct {
+	Namespace   string            // 指标名称前缀，默认为 swiftx
+	ConstLabels prometheus.Labels // 所有指标共有的标签，比如账号
+	Buckets     []float64         // 请求耗时的分桶（秒），默认为 prometheus.DefBuckets
+}
+
+// Collector Prometheus 指标收集器
+type Collector struct {
+	requests         *prometheus.CounterVec
+	duration         *prometheus.HistogramVec
+	httpErrors       *prometheus.CounterVec
+	businessFailures *prometheus.CounterVec
+	itemFailures     *prometheus.CounterVec
+	labels           prometheus.Counter
+	cancellations    prometheus.Counter
+}
+
+var _ prometheus.Collector = (*Collector)(nil)
+
+// NewCollector 创建指标收集器
+func NewCollector(opts CollectorOpts) *Collector {
+	namespace := opts.Namespace
+	if namespace == "" {
+		namespace = "swiftx"
+	}
+	buckets := opts.Buckets
+	if len(buckets) == 0 {
+		buckets = prometheus.DefBuckets
+	}
+	return &Collector{
+		requests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace:   namespace,
+			Name:        "requests_total",
+			Help:        "SwiftX API 请求数，code 为 HTTP 状态码，请求未发送成功时为 error。",
+			ConstLabels: opts.ConstLabels,
+		}, []string{"endpoint", "code"}),
+		duration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace:   namespace,
+			Name:        "request_duration_seconds",
+			Help:        "SwiftX API 请求耗时（包括限流等待和重试）。",
+			ConstLabels: opts.ConstLabels,
+			Buckets:     buckets,
+		}, []string{"endpoint"}),
+		httpErrors: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace:   namespace,
+			Name:        "http_errors_total",
+			Help:        "SwiftX API HTTP 错误数，class 为 4xx、5xx 或 429。",
+			ConstLabels: opts.ConstLabels,
+		}, []string{"endpoint", "class"}),
+		businessFailures: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace:   namespace,
+			Name:        "business_failures_total",
+			Help:        "SwiftX API 业务失败数（响应中的 success 为 false）。",
+			ConstLabels: opts.ConstLabels,
+		}, []string{"endpoint"}),
+		itemFailures: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace:   namespace,
+			Name:        "item_failures_total",
+			Help:        "SwiftX API 批量查询（轨迹、价格）中单个订单号的业务失败数（订单号结果中的 success 为 false）。",
+			ConstLabels: opts.ConstLabels,
+		}, []string{"endpoint"}),
+		labels: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace:   namespace,
+			Name:        "labels_created_total",
+			Help:        "成功创建的面单数。",
+			ConstLabels: opts.ConstLabels,
+		}),
+		cancellations: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace:   namespace,
+			Name:        "orders_cancelled_total",
+			Help:        "成功取消的订单数。",
+			ConstLabels: opts.ConstLabels,
+		}),
+	}
+}
+
+func (c *Collector) collectors() []prometheus.Collector {
+	return []prometheus.Collector{c.requests, c.duration, c.httpErrors, c.businessFailures, c.itemFailures, c.labels, c.cancellations}
+}
+
+// Describe 实现 prometheus.Collector
+func (c *Collector) Describe(ch chan<- *prometheus.Desc) {
+	for _, collector := range c.collectors() {
+		collector.Describe(ch)
+	}
+}
+
+// Collect 实现 prometheus.Collector
+func (c *Collector) Collect(ch chan<- prometheus.Metric) {
+	for _, collector := range c.collectors() {
+		collector.Collect(ch)
+	}
+}
+
+// Option 返回记录指标的 Client 选项
+func (c *Collector) Option() swiftx.Option {
+	return swiftx.WithMiddleware(c.Middleware())
+}
+
+// Middleware 返回记录指标的中间件
+func (c *Collector) Middleware() swiftx.Middleware {
+	return func(next swiftx.Handler) swiftx.Handler {
+		return swiftx.HandlerFunc(func(ctx context.Context, call *swiftx.Call) error {
+			err := next.Handle(ctx, call)
+			// 试运行的调用不会发送到 SwiftX，不计入指标
+			if !call.DryRun {
+				c.observe(call, err)
+			}
+			return err
+		})
+	}
+}
+
+func (c *Collector) observe(call *swiftx.Call, err error) {
+	code := "error"
+	if call.StatusCode != 0 {
+		code = strconv.Itoa(call.StatusCode)
+	}
+	c.requests.WithLabelValues(call.Endpoint, code).Inc()
+	c.duration.WithLabelValues(call.Endpoint).Observe(call.Latency.Seconds())
+
+	switch {
+	case call.StatusCode == 429:
+		c.httpErrors.WithLabelValues(call.Endpoint, "429").Inc()
+	case call.StatusCode >= 500:
+		c.httpErrors.WithLabelValues(call.Endpoint, "5xx").Inc()
+	case call.StatusCode >= 400:
+		c.httpErrors.WithLabelValues(call.Endpoint, "4xx").Inc()
+	}
+
+	var resultErr *swiftx.ResultError
+	if errors.As(err, &resultErr) {
+		c.businessFailures.WithLabelValues(call.Endpoint).Inc()
+	}
+	if err != nil {
+		return
+	}
+	for _, result := range call.ItemResults() {
+		if !result.Success {
+			c.itemFailures.WithLabelValues(call.Endpoint).Inc()
+		}
+	}
+	switch call.Endpoint {
+	case swiftx.EndpointCreateOrder:
+		if result, ok := call.Result.(*swiftx.CreateOrderResult); ok && result.PdfBase64 != "" {
+			c.labels.Inc()
+		}
+	case swiftx.EndpointCancelOrder:
+		c.cancellations.Inc()
+	}
+}
@@ -0,0 +1,140 @@
+package promswiftx
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/hiscaler/swiftx-go"
+	"github.com/hiscaler/swiftx-go/config"
+	"github.com/hiscaler/swiftx-go/entity"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+)
+
+// redirectTransport 将请求转发到测试服务器
+type redirectTransport struct {
+	target *url.URL
+}
+
+func (t redirectTransport) RoundTrip(r *http.Request) (*http.Response, error) {
+	r = r.Clone(r.Context())
+	r.URL.Scheme = t.target.Scheme
+	r.URL.Host = t.target.Host
+	return http.DefaultTransport.RoundTrip(r)
+}
+
+func TestCollector(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/api/v2/openapi/" + swiftx.EndpointCancelOrder:
+			var body struct {
+				TrackingNo string `json:"trackingNo"`
+			}
+			_ = json.NewDecoder(r.Body).Decode(&body)
+			if body.TrackingNo == "SWX1" {
+				fmt.Fprint(w, `{"success":true}`)
+			} else {
+				fmt.Fprint(w, `{"success":false,"message":"订单已揽收"}`)
+			}
+		case "/api/v2/openapi/" + swiftx.EndpointBatchGetTracking:
+			w.WriteHeader(http.StatusBadRequest)
+		case "/api/v2/openapi/" + swiftx.EndpointBatchGetPrice:
+			fmt.Fprint(w, `[{"result":{"success":true},"trackingNo":"SWX1"},{"result":{"success":false,"message":"订单不存在"},"trackingNo":"SWX2"},{"result":{"success":false},"trackingNo":"SWX3"}]`)
+		default:
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+	}))
+	defer srv.Close()
+	target, _ := url.Parse(srv.URL)
+
+	collector := NewCollector(CollectorOpts{ConstLabels: prometheus.Labels{"account": "a"}})
+	registry := prometheus.NewPedanticRegistry()
+	assert.NoError(t, registry.Register(collector))
+
+	client := swiftx.NewClient(config.Config{
+		Env:       entity.Test,
+		Timeout:   5,
+		AppKey:    "test-app-key",
+		AppSecret: "test-app-secret",
+	}, swiftx.WithTransport(redirectTransport{target: target}), collector.Option())
+
+	ctx := context.Background()
+	_, err := client.Services.Order.Cancel(ctx, "SWX1")
+	assert.NoError(t, err)
+	_, err = client.Services.Order.Cancel(ctx, "SWX2")
+	assert.Error(t, err)
+	_, err = client.Services.Order.Tracking(ctx, "SWX1")
+	assert.Error(t, err)
+	_, err = client.Services.Order.Postage(ctx, "SWX1", "SWX2", "SWX3")
+	assert.NoError(t, err)
+
+	assert.Equal(t, float64(2), testutil.ToFloat64(collector.requests.WithLabelValues(swiftx.EndpointCancelOrder, "200")))
+	assert.Equal(t, float64(1), testutil.ToFloat64(collector.requests.WithLabelValues(swiftx.EndpointBatchGetTracking, "400")))
+	assert.Equal(t, float64(1), testutil.ToFloat64(collector.httpErrors.WithLabelValues(swiftx.EndpointBatchGetTracking, "4xx")))
+	assert.Equal(t, float64(1), testutil.ToFloat64(collector.businessFailures.WithLabelValues(swiftx.EndpointCancelOrder)))
+	assert.Equal(t, float64(0), testutil.ToFloat64(collector.businessFailures.WithLabelValues(swiftx.EndpointBatchGetPrice)))
+	assert.Equal(t, float64(2), testutil.ToFloat64(collector.itemFailures.WithLabelValues(swiftx.EndpointBatchGetPrice)))
+	assert.Equal(t, float64(1), testutil.ToFloat64(collector.cancellations))
+	assert.Equal(t, float64(0), testutil.ToFloat64(collector.labels))
+
+	n, err := testutil.GatherAndCount(registry, "swiftx_request_duration_seconds")
+	assert.NoError(t, err)
+	assert.Equal(t, 3, n)
+}
+
+func TestCollector_DryRun(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `[{"trackingNo":"SWX1","success":true}]`)
+	}))
+	defer srv.Close()
+	target, _ := url.Parse(srv.URL)
+
+	collector := NewCollector(CollectorOpts{})
+	client := swiftx.NewClient(config.Config{
+		Env:       entity.Test,
+		Timeout:   5,
+		AppKey:    "test-app-key",
+		AppSecret: "test-app-secret",
+	}, swiftx.WithTransport(redirectTransport{target: target}), swiftx.WithDryRun(true), collector.Option())
+
+	ctx := context.Background()
+	_, err := client.Services.Order.Create(ctx, swiftx.CreateOrderRequest{
+		OrderScope:        entity.OrderScopeDomestic,
+		ServiceType:       entity.ServiceTypeExp,
+		DeliveryMethod:    entity.DeliveryMethodHdy,
+		CooperationMethod: entity.CooperationMethodMerchant,
+		PackageInfo: swiftx.CreateOrderPackageInformation{
+			SenderAddress:    swiftx.SenderAddress{Name: "ZEB2", RegionCode: "US", StateProvince: "CA", City: "Ontario", StreetAddress: "2078 E Francis Street", PostalCode: "91761"},
+			RecipientAddress: swiftx.RecipientAddress{Name: "Dak Jaech", PhoneNumber: "13474473197", RegionCode: "US", StateProvince: "TX", City: "Fort Worth", StreetAddress: "W1302 WELCH RD", PostalCode: "76118"},
+			Weight:           1.5,
+			Length:           10,
+			Width:            10,
+			Height:           5,
+			Value:            swiftx.Value{Money: entity.NewMoney(10000, entity.CurrencyUSD)},
+			SkuList:          []swiftx.CreateOrderPackageGoods{{Name: "SKU 1", Quantity: 1}},
+		},
+		ShippingLabelInfo: swiftx.ShippingLabelInformation{OrderNumber: "TEST-ORDER-1"},
+	})
+	assert.NoError(t, err)
+	_, err = client.Services.Order.Cancel(ctx, "SWX1")
+	assert.NoError(t, err)
+
+	// 试运行的调用不计入指标
+	assert.Equal(t, float64(0), testutil.ToFloat64(collector.labels))
+	assert.Equal(t, float64(0), testutil.ToFloat64(collector.cancellations))
+	assert.Equal(t, 0, testutil.CollectAndCount(collector.requests))
+	assert.Equal(t, 0, testutil.CollectAndCount(collector.duration))
+
+	// 查询类接口不受试运行模式影响
+	_, err = client.Services.Order.Tracking(ctx, "SWX1")
+	assert.NoError(t, err)
+	assert.Equal(t, float64(1), testutil.ToFloat64(collector.requests.WithLabelValues(swiftx.EndpointBatchGetTracking, "200")))
+}
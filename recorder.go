@@ -0,0 +1,218 @@
+package swiftx
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// ErrNoRecording 回放时没有找到匹配的记录
+var ErrNoRecording = errors.New("没有匹配的请求记录")
+
+// Recording 请求和响应记录（JSON Lines 中的一行）
+type Recording struct {
+	Time           time.Time       `json:"time"`                   // 请求发送时间
+	Duration       time.Duration   `json:"duration"`               // 耗时（纳秒）
+	Method         string          `json:"method"`                 // HTTP 方法
+	URL            string          `json:"url"`                    // 请求地址
+	Endpoint       string          `json:"endpoint"`               // 接口名称
+	BodySHA256     string          `json:"bodySha256"`             // 原始请求内容的 SHA256 哈希值，用于回放时匹配请求
+	RequestHeader  http.Header     `json:"requestHeader"`          // 请求头（已脱敏）
+	RequestBody    json.RawMessage `json:"requestBody,omitempty"`  // 请求内容（已脱敏）
+	StatusCode     int             `json:"statusCode,omitempty"`   // HTTP 状态码
+	ResponseHeader http.Header     `json:"responseHeader"`         // 响应头
+	ResponseBody   json.RawMessage `json:"responseBody,omitempty"` // JSON 格式的响应内容（已脱敏）
+	ResponseText   string          `json:"responseText,omitempty"` // 其他格式的响应内容
+	Error          string          `json:"error,omitempty"`        // 请求失败时的错误信息
+}
+
+// RecordingTransport 记录请求和响应的 HTTP Transport
+//
+// 通过 WithTransport 设置，记录的是签名后实际发送的请求。每个请求和响应脱敏后作为一行 JSON 写入 w。
+type RecordingTransport struct {
+	next      http.RoundTripper
+	redaction Redaction
+	mu        sync.Mutex
+	w         io.Writer
+}
+
+// NewRecordingTransport 创建记录请求和响应的 HTTP Transport，next 为空时使用 http.DefaultTransport
+func NewRecordingTransport(w io.Writer, next http.RoundTripper, redaction Redaction) *RecordingTransport {
+	if next == nil {
+		next = http.DefaultTransport
+	}
+	return &RecordingTransport{next: next, redaction: redaction, w: w}
+}
+
+func (t *RecordingTransport) RoundTrip(r *http.Request) (*http.Response, error) {
+	body, err := readBody(r)
+	if err != nil {
+		return nil, err
+	}
+	recording := Recording{
+		Time:          time.Now(),
+		Method:        r.Method,
+		URL:           r.URL.String(),
+		Endpoint:      path.Base(r.URL.Path),
+		BodySHA256:    contentSHA256(body),
+		RequestHeader: t.redaction.Header(r.Header),
+		RequestBody:   rawJSON(t.redaction.JSON(body)),
+	}
+
+	resp, err := t.next.RoundTrip(r)
+	recording.Duration = time.Since(recording.Time)
+	if err != nil {
+		recording.Error = err.Error()
+		return nil, errors.Join(err, t.write(recording))
+	}
+
+	respBody, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(respBody))
+	recording.StatusCode = resp.StatusCode
+	recording.ResponseHeader = resp.Header.Clone()
+	if json.Valid(respBody) {
+		recording.ResponseBody = t.redaction.JSON(respBody)
+	} else {
+		recording.ResponseText = string(respBody)
+	}
+	if err = t.write(recording); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (t *RecordingTransport) write(recording Recording) error {
+	b, err := json.Marshal(recording)
+	if err != nil {
+		return err
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if _, err = t.w.Write(append(b, '\n')); err != nil {
+		return fmt.Errorf("failed to write recording: %w", err)
+	}
+	return nil
+}
+
+// rawJSON 有效的 JSON 原样返回，否则编码为 JSON 字符串（只用于查看）
+func rawJSON(data []byte) json.RawMessage {
+	if len(data) == 0 {
+		return nil
+	}
+	if json.Valid(data) {
+		return data
+	}
+	b, _ := json.Marshal(string(data))
+	return b
+}
+
+// ReplayTransport 回放记录的响应的 HTTP Transport
+//
+// 按接口名称、查询字符串和请求内容的 SHA256 哈希值匹配记录，同一请求有多条记录时按记录顺序返回，最后一条记录会重复使用。
+type ReplayTransport struct {
+	mu         sync.Mutex
+	recordings map[string][]Recording // 接口名称 + 查询字符串 + 请求内容哈希值 => 记录
+}
+
+// NewReplayTransport 从 JSON Lines 格式的记录中创建回放 HTTP Transport
+func NewReplayTransport(r io.Reader) (*ReplayTransport, error) {
+	t := &ReplayTransport{recordings: make(map[string][]Recording)}
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 64*1024*1024)
+	line := 0
+	for scanner.Scan() {
+		line++
+		if len(bytes.TrimSpace(scanner.Bytes())) == 0 {
+			continue
+		}
+		var recording Recording
+		if err := json.Unmarshal(scanner.Bytes(), &recording); err != nil {
+			return nil, fmt.Errorf("line %d: %w", line, err)
+		}
+		u, err := url.Parse(recording.URL)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", line, err)
+		}
+		key := replayKey(recording.Endpoint, u.RawQuery, recording.BodySHA256)
+		t.recordings[key] = append(t.recordings[key], recording)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return t, nil
+}
+
+// LoadReplayTransport 从 JSON Lines 格式的记录文件中创建回放 HTTP Transport
+func LoadReplayTransport(filename string) (*ReplayTransport, error) {
+	f, err := os.Open(filename)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return NewReplayTransport(f)
+}
+
+func replayKey(endpoint, query, bodySHA256 string) string {
+	return endpoint + "\n" + query + "\n" + bodySHA256
+}
+
+func (t *ReplayTransport) RoundTrip(r *http.Request) (*http.Response, error) {
+	body, err := readBody(r)
+	if err != nil {
+		return nil, err
+	}
+	endpoint := path.Base(r.URL.Path)
+	key := replayKey(endpoint, r.URL.RawQuery, contentSHA256(body))
+
+	t.mu.Lock()
+	recordings := t.recordings[key]
+	if len(recordings) == 0 {
+		t.mu.Unlock()
+		return nil, fmt.Errorf("%w: %s %s", ErrNoRecording, r.Method, endpoint)
+	}
+	recording := recordings[0]
+	if len(recordings) > 1 {
+		t.recordings[key] = recordings[1:]
+	}
+	t.mu.Unlock()
+
+	if recording.Error != "" {
+		return nil, errors.New(recording.Error)
+	}
+	respBody := []byte(recording.ResponseText)
+	if len(recording.ResponseBody) > 0 {
+		respBody = recording.ResponseBody
+	}
+	header := recording.ResponseHeader.Clone()
+	if header == nil {
+		header = http.Header{}
+	}
+	// 记录中的 Date 与当前时间不同，回放时不用于校正签名时间
+	header.Del("Date")
+	header.Set("Content-Length", strconv.Itoa(len(respBody)))
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", recording.StatusCode, http.StatusText(recording.StatusCode)),
+		StatusCode:    recording.StatusCode,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          io.NopCloser(bytes.NewReader(respBody)),
+		ContentLength: int64(len(respBody)),
+		Request:       r,
+	}, nil
+}
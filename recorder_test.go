@@ -0,0 +1,71 @@
+package swiftx
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/hiscaler/swiftx-go/config"
+	"github.com/hiscaler/swiftx-go/entity"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRecordingTransport(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/api/v2/openapi/" + EndpointBatchGetTracking:
+			var body struct {
+				TrackingNoList []string `json:"trackingNoList"`
+			}
+			_ = json.NewDecoder(r.Body).Decode(&body)
+			fmt.Fprintf(w, `[{"trackingNo":%q,"result":{"success":true},"trackingEventList":[{"event":"DELIVERED"}]}]`, body.TrackingNoList[0])
+		default:
+			fmt.Fprint(w, r.URL.Query().Get("i"))
+		}
+	}))
+	defer srv.Close()
+
+	var buf bytes.Buffer
+	c := newTestClient(t, nil, WithTransport(NewRecordingTransport(&buf, nil, Redaction{})))
+	c.httpClient.SetBaseURL(srv.URL + "/api/v2/openapi")
+	recorded, err := c.Services.Order.Tracking(ctx, "SWX1")
+	assert.NoError(t, err)
+	_, err = c.Services.Order.Tracking(ctx, "SWX2")
+	assert.NoError(t, err)
+	n, err := c.Services.Ping.Pong(ctx, 7)
+	assert.NoError(t, err)
+	assert.Equal(t, 7, n)
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if assert.Len(t, lines, 3) {
+		var recording Recording
+		assert.NoError(t, json.Unmarshal([]byte(lines[0]), &recording))
+		assert.Equal(t, EndpointBatchGetTracking, recording.Endpoint)
+		assert.Equal(t, http.StatusOK, recording.StatusCode)
+		assert.Equal(t, recording.BodySHA256, recording.RequestHeader.Get(HeaderContentSHA256))
+		assert.Equal(t, Redacted, recording.RequestHeader.Get(HeaderSignature))
+		assert.JSONEq(t, `{"trackingNoList":["SWX1"]}`, string(recording.RequestBody))
+	}
+
+	// 回放时不需要服务端
+	replay, err := NewReplayTransport(bytes.NewReader(buf.Bytes()))
+	assert.NoError(t, err)
+	c = NewClient(config.Config{Env: entity.Test, Timeout: 5, AppKey: "other-key", AppSecret: "other-secret"}, WithTransport(replay))
+	replayed, err := c.Services.Order.Tracking(ctx, "SWX1")
+	assert.NoError(t, err)
+	assert.Equal(t, recorded, replayed)
+	n, err = c.Services.Ping.Pong(ctx, 7)
+	assert.NoError(t, err)
+	assert.Equal(t, 7, n)
+
+	_, err = c.Services.Order.Tracking(ctx, "SWX3")
+	assert.True(t, errors.Is(err, ErrNoRecording))
+	_, err = c.Services.Ping.Pong(ctx, 8)
+	assert.True(t, errors.Is(err, ErrNoRecording))
+}
@@ -0,0 +1,188 @@
+package swiftx
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/go-resty/resty/v2"
+)
+
+// Redacted 脱敏后的值
+const Redacted = "[REDACTED]"
+
+var (
+	// DefaultRedactedAddressFields 默认脱敏的地址字段（JSON 名称），保留国家、州/省、城市等用于排查问题的字段
+	DefaultRedactedAddressFields = []string{"name", "phoneNumber", "phoneExtension", "streetAddress", "building", "postalCode"}
+	// DefaultRedactedHeaders 默认脱敏的请求头
+	DefaultRedactedHeaders = []string{HeaderSignature}
+)
+
+// 包含 entity.Address 的 JSON 字段
+var addressKeys = map[string]bool{
+	"senderAddress":    true,
+	"recipientAddress": true,
+}
+
+// 面单 PDF 的 Base64 编码内容的 JSON 字段
+const labelKey = "pdfBase64"
+
+// Redaction 日志脱敏配置，同时用于请求日志和调试模式（Config.Debug）输出的请求、响应内容
+type Redaction struct {
+	AddressFields []string // 需要脱敏的地址字段（JSON 名称），为 nil 时使用 DefaultRedactedAddressFields
+	Headers       []string // 需要脱敏的请求头，为 nil 时使用 DefaultRedactedHeaders
+	KeepLabel     bool     // 是否保留面单 PDF 的 Base64 编码内容，默认只记录长度
+}
+
+// WithRedaction 设置日志脱敏配置
+func WithRedaction(redaction Redaction) Option {
+	return func(c *Client) {
+		c.redaction = redaction
+	}
+}
+
+// Header 返回脱敏后的请求头副本
+func (r Redaction) Header(header http.Header) http.Header {
+	header = header.Clone()
+	names := r.Headers
+	if names == nil {
+		names = DefaultRedactedHeaders
+	}
+	for _, name := range names {
+		if header.Get(name) != "" {
+			header.Set(name, Redacted)
+		}
+	}
+	return header
+}
+
+// JSON 返回脱敏后的 JSON 内容，无效的 JSON 原样返回
+func (r Redaction) JSON(data []byte) []byte {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	var v any
+	if err := decoder.Decode(&v); err != nil {
+		return data
+	}
+	b, err := json.Marshal(r.redact("", v))
+	if err != nil {
+		return data
+	}
+	return b
+}
+
+// Value 返回 v 序列化并脱敏后的 JSON 内容
+func (r Redaction) Value(v any) json.RawMessage {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return nil
+	}
+	return r.JSON(b)
+}
+
+func (r Redaction) redact(key string, v any) any {
+	switch value := v.(type) {
+	case map[string]any:
+		if addressKeys[key] {
+			fields := r.AddressFields
+			if fields == nil {
+				fields = DefaultRedactedAddressFields
+			}
+			for _, field := range fields {
+				if s, ok := value[field].(string); ok && s != "" {
+					value[field] = Redacted
+				}
+			}
+			return value
+		}
+		for k, item := range value {
+			value[k] = r.redact(k, item)
+		}
+	case []any:
+		for i, item := range value {
+			value[i] = r.redact(key, item)
+		}
+	case string:
+		if key == labelKey && !r.KeepLabel && value != "" {
+			return fmt.Sprintf("[REDACTED %d bytes]", len(value))
+		}
+	}
+	return v
+}
+
+// redactDebugLog 调试模式下对 resty 输出的请求、响应内容脱敏
+func (r Redaction) redactDebugLog(httpClient *resty.Client) {
+	httpClient.
+		OnRequestLog(func(log *resty.RequestLog) error {
+			log.Header = r.Header(log.Header)
+			log.Body = string(r.JSON([]byte(log.Body)))
+			return nil
+		}).
+		OnResponseLog(func(log *resty.ResponseLog) error {
+			log.Body = string(r.JSON([]byte(log.Body)))
+			return nil
+		})
+}
+
+// RequestLogOptions 请求日志配置
+type RequestLogOptions struct {
+	Level slog.Level // 成功请求的日志级别，默认为 slog.LevelInfo，失败的请求使用 slog.LevelWarn
+	Body  bool       // 是否记录脱敏后的请求和响应内容
+}
+
+// WithRequestLog 使用 Config.Logger（默认为 slog.Default()）记录每次 API 调用的接口名称、状态码、耗时和订单号
+func WithRequestLog(opts RequestLogOptions) Option {
+	return func(c *Client) {
+		c.requestLog = &opts
+	}
+}
+
+// requestLogMiddleware 请求日志中间件
+func requestLogMiddleware(logger *slog.Logger, opts RequestLogOptions, redaction Redaction) Middleware {
+	return func(next Handler) Handler {
+		return HandlerFunc(func(ctx context.Context, call *Call) error {
+			err := next.Handle(ctx, call)
+
+			level := opts.Level
+			if err != nil {
+				level = slog.LevelWarn
+			}
+			if !logger.Enabled(ctx, level) {
+				return err
+			}
+			attrs := []slog.Attr{
+				slog.String("endpoint", call.Endpoint),
+				slog.String("method", call.Method),
+				slog.Int("status", call.StatusCode),
+				slog.Duration("latency", call.Latency.Round(time.Microsecond)),
+				slog.Int("attempts", call.Attempts),
+			}
+			if call.RequestID != "" {
+				attrs = append(attrs, slog.String("request_id", call.RequestID))
+			}
+			if numbers := call.TrackingNumbers(); len(numbers) > 0 {
+				attrs = append(attrs, slog.Any("tracking_numbers", numbers))
+			}
+			if opts.Body {
+				if call.SignedHeader != nil {
+					attrs = append(attrs, slog.Any("header", redaction.Header(call.SignedHeader)))
+				}
+				if call.Body != nil {
+					attrs = append(attrs, slog.String("request", string(redaction.Value(call.Body))))
+				}
+				if call.Result != nil && call.StatusCode != 0 {
+					attrs = append(attrs, slog.String("response", string(redaction.Value(call.Result))))
+				}
+			}
+			if err != nil {
+				attrs = append(attrs, slog.String("error", err.Error()), slog.String("error_category", call.ErrorCategory(err)))
+			}
+			logger.LogAttrs(ctx, level, "swiftx request", attrs...)
+			return err
+		})
+	}
+}
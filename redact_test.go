@@ -0,0 +1,134 @@
+package swiftx
+
+import (
+	"bytes"
+	"encoding/base64"
+	"fmt"
+	"log"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hiscaler/swiftx-go/config"
+	"github.com/hiscaler/swiftx-go/entity"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRedaction_JSON(t *testing.T) {
+	data := []byte(`{"packageInfo":{"recipientAddress":{"name":"Dak Jaech","phoneNumber":"1096398373","city":"Fort Worth","postalCode":"76118"},"weight":1.5},"pdfBase64":"JVBERi0xLjQK"}`)
+
+	redacted := string(Redaction{}.JSON(data))
+	assert.NotContains(t, redacted, "Dak Jaech")
+	assert.NotContains(t, redacted, "1096398373")
+	assert.NotContains(t, redacted, "76118")
+	assert.NotContains(t, redacted, "JVBERi0xLjQK")
+	assert.Contains(t, redacted, `"city":"Fort Worth"`)
+	assert.Contains(t, redacted, `"weight":1.5`)
+	assert.Contains(t, redacted, `"pdfBase64":"[REDACTED 12 bytes]"`)
+
+	redacted = string(Redaction{AddressFields: []string{"phoneNumber"}, KeepLabel: true}.JSON(data))
+	assert.Contains(t, redacted, "Dak Jaech")
+	assert.NotContains(t, redacted, "1096398373")
+	assert.Contains(t, redacted, "JVBERi0xLjQK")
+
+	assert.Equal(t, "not json", string(Redaction{}.JSON([]byte("not json"))))
+
+	header := http.Header{}
+	header.Set(HeaderSignature, "abc")
+	header.Set(HeaderAppKey, "key")
+	redactedHeader := Redaction{}.Header(header)
+	assert.Equal(t, Redacted, redactedHeader.Get(HeaderSignature))
+	assert.Equal(t, "key", redactedHeader.Get(HeaderAppKey))
+	assert.Equal(t, "abc", header.Get(HeaderSignature))
+}
+
+func TestClient_RequestLog(t *testing.T) {
+	label := base64.StdEncoding.EncodeToString([]byte("%PDF-1.4 label"))
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if strings.HasSuffix(r.URL.Path, EndpointBatchGetTracking) {
+			fmt.Fprint(w, `[]`)
+			return
+		}
+		fmt.Fprintf(w, `{"result":{"success":true},"trackingNo":"SWX1","pdfBase64":%q}`, label)
+	}))
+	defer srv.Close()
+
+	var buf bytes.Buffer
+	c := NewClient(config.Config{
+		Debug:     true,
+		Env:       entity.Test,
+		Timeout:   5,
+		AppKey:    "test-app-key",
+		AppSecret: "test-app-secret",
+		Logger:    slog.New(slog.NewTextHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug})),
+	}, WithRequestLog(RequestLogOptions{Body: true}))
+	c.httpClient.SetBaseURL(srv.URL).SetRetryWaitTime(time.Millisecond)
+
+	recipient := RecipientAddress{
+		RegionCode:    "US",
+		StateProvince: "TX",
+		City:          "Fort Worth",
+		StreetAddress: "W1302 WELCH RD",
+		PostalCode:    "76118",
+		Name:          "Dak Jaech",
+		PhoneNumber:   "+1 347-447-3197",
+	}
+	sender := recipient
+	sender.Name = "ZEB2"
+	_, err := c.Services.Order.Create(ctx, CreateOrderRequest{
+		OrderScope:        entity.OrderScopeDomestic,
+		ServiceType:       entity.ServiceTypeExp,
+		DeliveryMethod:    entity.DeliveryMethodHdy,
+		CooperationMethod: entity.CooperationMethodMerchant,
+		PackageInfo: CreateOrderPackageInformation{
+			SenderAddress:    sender,
+			RecipientAddress: recipient,
+			Weight:           1.5,
+			Length:           10,
+			Width:            10,
+			Height:           5,
+			Value:            Value{Money: entity.NewMoney(10000, entity.CurrencyUSD)},
+			SkuList:          []CreateOrderPackageGoods{{Name: "SKU 1", Quantity: 1}},
+		},
+		ShippingLabelInfo: ShippingLabelInformation{OrderNumber: "TEST-ORDER-1"},
+	})
+	assert.NoError(t, err)
+
+	_, err = c.Services.Order.Tracking(ctx, "SWX1", "SWX2")
+	assert.NoError(t, err)
+
+	output := buf.String()
+	assert.Contains(t, output, "msg=\"swiftx request\" endpoint="+EndpointCreateOrder)
+	assert.Contains(t, output, "status=200")
+	assert.Contains(t, output, "tracking_numbers=\"[SWX1 SWX2]\"")
+	assert.Contains(t, output, "~~~ REQUEST ~~~", "resty debug output should go through the logger")
+	assert.Contains(t, output, "Fort Worth")
+	for _, secret := range []string{"Dak Jaech", "ZEB2", "W1302 WELCH RD", "347-447-3197", label} {
+		assert.False(t, strings.Contains(output, secret), "log should not contain %q", secret)
+	}
+	assert.Contains(t, output, "X-Signature:["+Redacted+"]")
+}
+
+func TestClient_DebugLogDefaultHandler(t *testing.T) {
+	// slog.Default() 的默认处理器输出到 log 包，日志级别为 Info
+	var buf bytes.Buffer
+	log.SetOutput(&buf)
+	defer log.SetOutput(os.Stderr)
+
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, r.URL.Query().Get("i"))
+	})
+	c.httpClient.SetDebug(true)
+	_, err := c.Services.Ping.Pong(ctx, 1)
+	assert.NoError(t, err)
+	output := buf.String()
+	assert.Contains(t, output, "~~~ REQUEST ~~~")
+	assert.Contains(t, output, "/pingPong?i=1")
+	assert.Contains(t, output, "~~~ RESPONSE ~~~")
+}
@@ -0,0 +1,340 @@
+package swiftx
+
+import (
+	"container/list"
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"sync"
+
+	"github.com/hiscaler/swiftx-go/config"
+	"github.com/hiscaler/swiftx-go/entity"
+	"github.com/hiscaler/swiftx-go/response"
+)
+
+// ErrAccountNotFound 账号不存在
+var ErrAccountNotFound = errors.New("账号不存在")
+
+// RegistryConfig 多账号注册表配置
+type RegistryConfig struct {
+	Transport    http.RoundTripper // 所有账号共用的 HTTP Transport，设置后账号配置中的代理、TLS 和连接池设置无效；为空时按账号配置创建，设置相同的账号共用同一个 Transport
+	RateLimiter  RateLimiter       // 所有账号共用的限流器，为空时不限流
+	Options      []Option          // 所有账号共用的 Client 选项
+	MaxShipments int               // 记录订单号所属账号的最大数量，超过时淘汰最久未使用的记录，为 0 时使用 DefaultMaxShipments，小于 0 时不记录
+}
+
+// DefaultMaxShipments 默认记录订单号所属账号的最大数量
+const DefaultMaxShipments = 10000
+
+// Registry 多账号注册表，按账号 ID 管理多个 Client
+//
+// 所有 Client 共用同一个限流器，代理、TLS 和连接池设置相同的 Client 共用同一个 HTTP Transport，并记录订单号所属的账号（最多 MaxShipments 个），用于路由查询请求：
+// Tracking、Postage 将已知所属账号的订单号只发送到对应账号，其余订单号并发发送到所有账号，使用第一个查询成功的结果并记录订单号所属的账号。
+// 需要使用指定账号查询时，通过 Client 获取该账号的 Client。
+type Registry struct {
+	transport     http.RoundTripper
+	transports    map[transportSettings]http.RoundTripper // 未设置 Transport 时按账号配置创建的 Transport
+	rateLimiter   RateLimiter
+	options       []Option
+	mu            sync.RWMutex
+	clients       map[string]*Client
+	maxShipments  int
+	shipments     map[string]*list.Element // SwiftX 订单号 => shipmentOrder 中的 shipmentEntry
+	shipmentOrder *list.List               // 按最近使用排序，最近使用的在前
+}
+
+// shipmentEntry 订单号所属的账号
+type shipmentEntry struct {
+	shipmentNumber string
+	accountID      string
+}
+
+// AccountTrackingResult 账号物流跟踪结果
+type AccountTrackingResult struct {
+	AccountID string // 账号 ID，未在任何账号中找到时为空
+	entity.TrackingResult
+}
+
+// AccountOrderPrice 账号订单价格
+type AccountOrderPrice struct {
+	AccountID string          // 账号 ID，未在任何账号中找到时为空
+	Result    response.Result // 业务结果
+	entity.OrderPrice
+}
+
+// NewRegistry 创建多账号注册表
+func NewRegistry(cfg RegistryConfig) *Registry {
+	maxShipments := cfg.MaxShipments
+	if maxShipments == 0 {
+		maxShipments = DefaultMaxShipments
+	}
+	return &Registry{
+		transport:     cfg.Transport,
+		transports:    make(map[transportSettings]http.RoundTripper),
+		rateLimiter:   cfg.RateLimiter,
+		options:       cfg.Options,
+		clients:       make(map[string]*Client),
+		maxShipments:  maxShipments,
+		shipments:     make(map[string]*list.Element),
+		shipmentOrder: list.New(),
+	}
+}
+
+// Register 注册账号，账号已存在时替换原有的 Client
+func (r *Registry) Register(accountID string, cfg config.Config, opts ...Option) (*Client, error) {
+	if accountID == "" {
+		return nil, errors.New("账号 ID 不能为空")
+	}
+
+	settings := transportSettingsOf(cfg)
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	transport := r.transport
+	if transport == nil {
+		if transport = r.transports[settings]; transport == nil {
+			t, err := NewTransport(cfg, nil)
+			if err != nil {
+				return nil, err
+			}
+			transport = t
+			r.transports[settings] = transport
+		}
+	}
+
+	options := make([]Option, 0, len(r.options)+len(opts)+2)
+	options = append(options, WithTransport(transport))
+	if r.rateLimiter != nil {
+		options = append(options, WithRateLimiter(r.rateLimiter))
+	}
+	options = append(options, r.options...)
+	options = append(options, opts...)
+	c := NewClient(cfg, options...)
+	if r.transport != nil && settings != (transportSettings{}) {
+		c.logger.Warn("swiftx: 使用 RegistryConfig.Transport 时账号配置中的代理、TLS 和连接池设置无效", "account", accountID)
+	}
+	r.clients[accountID] = c
+	return c, nil
+}
+
+// Remove 移除账号
+func (r *Registry) Remove(accountID string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delete(r.clients, accountID)
+	for e := r.shipmentOrder.Front(); e != nil; {
+		next := e.Next()
+		if entry := e.Value.(*shipmentEntry); entry.accountID == accountID {
+			r.shipmentOrder.Remove(e)
+			delete(r.shipments, entry.shipmentNumber)
+		}
+		e = next
+	}
+}
+
+// Client 返回账号对应的 Client
+func (r *Registry) Client(accountID string) (*Client, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	c, ok := r.clients[accountID]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrAccountNotFound, accountID)
+	}
+	return c, nil
+}
+
+// Accounts 返回所有账号 ID（已排序）
+func (r *Registry) Accounts() []string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	ids := make([]string, 0, len(r.clients))
+	for id := range r.clients {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+// AccountOf 返回订单号所属的账号（通过 Registry 创建或查询到的订单）
+func (r *Registry) AccountOf(shipmentNumber string) (string, bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	e, ok := r.shipments[shipmentNumber]
+	if !ok {
+		return "", false
+	}
+	r.shipmentOrder.MoveToFront(e)
+	return e.Value.(*shipmentEntry).accountID, true
+}
+
+func (r *Registry) remember(accountID string, shipmentNumbers ...string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, ok := r.clients[accountID]; !ok || r.maxShipments < 0 {
+		return
+	}
+	for _, shipmentNumber := range shipmentNumbers {
+		if shipmentNumber == "" {
+			continue
+		}
+		if e, ok := r.shipments[shipmentNumber]; ok {
+			e.Value.(*shipmentEntry).accountID = accountID
+			r.shipmentOrder.MoveToFront(e)
+			continue
+		}
+		r.shipments[shipmentNumber] = r.shipmentOrder.PushFront(&shipmentEntry{shipmentNumber: shipmentNumber, accountID: accountID})
+		for r.shipmentOrder.Len() > r.maxShipments {
+			oldest := r.shipmentOrder.Back()
+			r.shipmentOrder.Remove(oldest)
+			delete(r.shipments, oldest.Value.(*shipmentEntry).shipmentNumber)
+		}
+	}
+}
+
+// Create 使用指定账号创建订单
+func (r *Registry) Create(ctx context.Context, accountID string, request CreateOrderRequest, opts ...CallOption) (entity.Order, error) {
+	c, err := r.Client(accountID)
+	if err != nil {
+		return entity.Order{}, err
+	}
+	order, err := c.Services.Order.Create(ctx, request, opts...)
+	if err != nil {
+		return order, err
+	}
+	r.remember(accountID, order.ShipmentNumber)
+	return order, nil
+}
+
+// Tracking 查询物流轨迹，订单号路由到所属的账号
+func (r *Registry) Tracking(ctx context.Context, shipmentNumbers ...string) ([]AccountTrackingResult, error) {
+	found, failed, err := route(ctx, r, shipmentNumbers,
+		func(ctx context.Context, c *Client, shipmentNumbers []string) ([]entity.TrackingResult, error) {
+			return c.Services.Order.Tracking(ctx, shipmentNumbers...)
+		},
+		func(result entity.TrackingResult) (string, bool) {
+			return result.TrackingNo, result.Result.Success
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]AccountTrackingResult, 0, len(shipmentNumbers))
+	for _, shipmentNumber := range shipmentNumbers {
+		if result, ok := found[shipmentNumber]; ok {
+			results = append(results, AccountTrackingResult{AccountID: result.accountID, TrackingResult: result.item})
+		} else if result, ok := failed[shipmentNumber]; ok {
+			results = append(results, AccountTrackingResult{TrackingResult: result})
+		}
+	}
+	return results, nil
+}
+
+// Postage 查询订单价格，订单号路由到所属的账号
+func (r *Registry) Postage(ctx context.Context, shipmentNumbers ...string) ([]AccountOrderPrice, error) {
+	found, failed, err := route(ctx, r, shipmentNumbers,
+		func(ctx context.Context, c *Client, shipmentNumbers []string) ([]postageResult, error) {
+			return c.Services.Order.postage(ctx, shipmentNumbers...)
+		},
+		func(result postageResult) (string, bool) {
+			return result.TrackingNo, result.Success
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	prices := make([]AccountOrderPrice, 0, len(shipmentNumbers))
+	for _, shipmentNumber := range shipmentNumbers {
+		if result, ok := found[shipmentNumber]; ok {
+			prices = append(prices, AccountOrderPrice{AccountID: result.accountID, Result: result.item.Result, OrderPrice: result.item.orderPrice()})
+		} else if result, ok := failed[shipmentNumber]; ok {
+			prices = append(prices, AccountOrderPrice{Result: result.Result, OrderPrice: result.orderPrice()})
+		}
+	}
+	return prices, nil
+}
+
+// routedItem 账号的查询结果
+type routedItem[T any] struct {
+	accountID string
+	item      T
+}
+
+// route 在不知道账号的情况下批量查询
+//
+// 已知所属账号的订单号只发送到对应账号，其余订单号并发发送到所有账号，使用第一个查询成功的结果并记录订单号所属的账号。
+// 所有账号都未查询到的订单号，返回最后一个失败结果。部分账号请求失败时仍返回其他账号的结果，
+// 只有全部请求都失败时才返回 error。
+func route[T any](
+	ctx context.Context,
+	r *Registry,
+	shipmentNumbers []string,
+	query func(ctx context.Context, c *Client, shipmentNumbers []string) ([]T, error),
+	result func(item T) (shipmentNumber string, success bool),
+) (map[string]routedItem[T], map[string]T, error) {
+	accounts := r.Accounts()
+	if len(accounts) == 0 {
+		return nil, nil, ErrAccountNotFound
+	}
+
+	batches := make(map[string][]string, len(accounts))
+	unknown := make([]string, 0)
+	for _, shipmentNumber := range shipmentNumbers {
+		if id, ok := r.AccountOf(shipmentNumber); ok {
+			batches[id] = append(batches[id], shipmentNumber)
+		} else {
+			unknown = append(unknown, shipmentNumber)
+		}
+	}
+	if len(unknown) > 0 {
+		for _, id := range accounts {
+			batches[id] = append(batches[id], unknown...)
+		}
+	}
+
+	type accountResults struct {
+		accountID string
+		items     []T
+		err       error
+	}
+	ch := make(chan accountResults, len(batches))
+	for id, numbers := range batches {
+		go func(id string, numbers []string) {
+			c, err := r.Client(id)
+			if err != nil {
+				ch <- accountResults{accountID: id, err: err}
+				return
+			}
+			items, err := query(ctx, c, numbers)
+			ch <- accountResults{accountID: id, items: items, err: err}
+		}(id, numbers)
+	}
+
+	found := make(map[string]routedItem[T], len(shipmentNumbers))
+	failed := make(map[string]T)
+	errs := make([]error, 0)
+	for range batches {
+		res := <-ch
+		if res.err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", res.accountID, res.err))
+			continue
+		}
+		for _, item := range res.items {
+			shipmentNumber, success := result(item)
+			if !success {
+				failed[shipmentNumber] = item
+				continue
+			}
+			if _, ok := found[shipmentNumber]; !ok {
+				found[shipmentNumber] = routedItem[T]{accountID: res.accountID, item: item}
+				r.remember(res.accountID, shipmentNumber)
+			}
+		}
+	}
+	if len(errs) == len(batches) {
+		return nil, nil, errors.Join(errs...)
+	}
+	return found, failed, nil
+}
@@ -0,0 +1,195 @@
+package swiftx
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/hiscaler/swiftx-go/config"
+	"github.com/hiscaler/swiftx-go/entity"
+	"github.com/hiscaler/swiftx-go/response"
+	"github.com/stretchr/testify/assert"
+)
+
+// countingLimiter 记录 Wait 调用次数的限流器
+type countingLimiter struct {
+	n atomic.Int32
+}
+
+func (l *countingLimiter) Wait(_ context.Context) error {
+	l.n.Add(1)
+	return nil
+}
+
+func TestRegistry(t *testing.T) {
+	// 每个账号只能查询到自己的订单
+	shipments := map[string]string{
+		"key-a": "SWX-A",
+		"key-b": "SWX-B",
+	}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body struct {
+			TrackingNoList []string `json:"trackingNoList"`
+		}
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		results := make([]map[string]any, 0)
+		for _, no := range body.TrackingNoList {
+			result := map[string]any{"trackingNo": no}
+			if shipments[r.Header.Get("X-App-Key")] == no {
+				result["result"] = response.Result{Success: true}
+				if strings.HasSuffix(r.URL.Path, EndpointBatchGetPrice) {
+					result["shippingCharge"] = map[string]any{"total": map[string]any{"amount": 12.5, "currencyCode": "USD"}}
+				} else {
+					result["trackingEventList"] = []entity.Track{{Event: "DELIVERED"}}
+				}
+			} else {
+				result["result"] = response.Result{Success: false, Message: "not found"}
+			}
+			results = append(results, result)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(results)
+	}))
+	defer srv.Close()
+
+	limiter := &countingLimiter{}
+	registry := NewRegistry(RegistryConfig{RateLimiter: limiter})
+	_, err := registry.Tracking(ctx, "SWX-A")
+	assert.True(t, errors.Is(err, ErrAccountNotFound))
+	var transport http.RoundTripper
+	for _, id := range []string{"a", "b"} {
+		c, err := registry.Register(id, config.Config{Env: entity.Test, Timeout: 5, AppKey: "key-" + id, AppSecret: "secret"})
+		assert.NoError(t, err)
+		c.httpClient.SetBaseURL(srv.URL)
+		// 设置相同的账号共用同一个 Transport
+		if transport == nil {
+			transport = c.httpClient.GetClient().Transport.(*signingTransport).next
+		}
+		assert.Same(t, transport, c.httpClient.GetClient().Transport.(*signingTransport).next)
+	}
+	assert.Equal(t, []string{"a", "b"}, registry.Accounts())
+	_, err = registry.Client("c")
+	assert.True(t, errors.Is(err, ErrAccountNotFound))
+
+	limiter.n.Store(0)
+	found, err := registry.Tracking(ctx, "SWX-B", "SWX-A", "SWX-C")
+	assert.NoError(t, err)
+	assert.Equal(t, int32(2), limiter.n.Load())
+	if assert.Len(t, found, 3) {
+		assert.Equal(t, "b", found[0].AccountID)
+		assert.Equal(t, "SWX-B", found[0].TrackingNo)
+		assert.Equal(t, "a", found[1].AccountID)
+		assert.Equal(t, "", found[2].AccountID)
+		assert.False(t, found[2].Result.Success)
+	}
+	id, ok := registry.AccountOf("SWX-B")
+	assert.True(t, ok)
+	assert.Equal(t, "b", id)
+
+	// 已知账号的订单号只发送到对应账号
+	limiter.n.Store(0)
+	found, err = registry.Tracking(ctx, "SWX-B")
+	assert.NoError(t, err)
+	assert.Equal(t, int32(1), limiter.n.Load())
+	assert.Equal(t, "b", found[0].AccountID)
+
+	// 查询价格使用相同的路由
+	limiter.n.Store(0)
+	prices, err := registry.Postage(ctx, "SWX-A", "SWX-C")
+	assert.NoError(t, err)
+	assert.Equal(t, int32(2), limiter.n.Load())
+	if assert.Len(t, prices, 2) {
+		assert.Equal(t, "a", prices[0].AccountID)
+		assert.True(t, prices[0].Result.Success)
+		assert.Equal(t, entity.NewMoney(1250, entity.CurrencyUSD), prices[0].Amount)
+		assert.Equal(t, "", prices[1].AccountID)
+		assert.Equal(t, "not found", prices[1].Result.Message)
+	}
+
+	registry.Remove("b")
+	_, ok = registry.AccountOf("SWX-B")
+	assert.False(t, ok)
+	_, ok = registry.AccountOf("SWX-A")
+	assert.True(t, ok)
+}
+
+func TestRegistry_MaxShipments(t *testing.T) {
+	registry := NewRegistry(RegistryConfig{MaxShipments: 2})
+	_, err := registry.Register("a", config.Config{Env: entity.Test, Timeout: 5, AppKey: "key", AppSecret: "secret"})
+	assert.NoError(t, err)
+
+	registry.remember("a", "SWX1", "SWX2")
+	// 访问 SWX1 后 SWX2 成为最久未使用的记录
+	_, ok := registry.AccountOf("SWX1")
+	assert.True(t, ok)
+	registry.remember("a", "SWX3")
+	_, ok = registry.AccountOf("SWX2")
+	assert.False(t, ok)
+	for _, shipmentNumber := range []string{"SWX1", "SWX3"} {
+		_, ok = registry.AccountOf(shipmentNumber)
+		assert.True(t, ok, shipmentNumber)
+	}
+	assert.Len(t, registry.shipments, 2)
+	assert.Equal(t, 2, registry.shipmentOrder.Len())
+
+	// 不记录订单号所属的账号
+	registry = NewRegistry(RegistryConfig{MaxShipments: -1})
+	_, err = registry.Register("a", config.Config{Env: entity.Test, Timeout: 5, AppKey: "key", AppSecret: "secret"})
+	assert.NoError(t, err)
+	registry.remember("a", "SWX1")
+	_, ok = registry.AccountOf("SWX1")
+	assert.False(t, ok)
+}
+
+func TestRegistry_Transport(t *testing.T) {
+	cfg := func(proxy string) config.Config {
+		return config.Config{Env: entity.Test, Timeout: 5, AppKey: "key", AppSecret: "secret", Proxy: proxy}
+	}
+	registry := NewRegistry(RegistryConfig{})
+	a, err := registry.Register("a", cfg("http://proxy-a:3128"))
+	assert.NoError(t, err)
+	b, err := registry.Register("b", cfg("http://proxy-a:3128"))
+	assert.NoError(t, err)
+	c, err := registry.Register("c", cfg("http://proxy-c:3128"))
+	assert.NoError(t, err)
+
+	// 设置相同的账号共用 Transport，各账号的代理设置生效
+	assert.Same(t, a.transport, b.transport)
+	assert.NotSame(t, a.transport, c.transport)
+	for client, host := range map[*Client]string{a: "proxy-a:3128", c: "proxy-c:3128"} {
+		transport, ok := client.transport.(*http.Transport)
+		if assert.True(t, ok) {
+			proxy, err := transport.Proxy(httptest.NewRequest(http.MethodGet, TestBaseUrl, nil))
+			assert.NoError(t, err)
+			assert.Equal(t, host, proxy.Host)
+		}
+	}
+
+	_, err = registry.Register("d", cfg("://invalid"))
+	assert.Error(t, err)
+
+	// 共用 Transport 时账号的代理设置无效，记录警告
+	var buf bytes.Buffer
+	shared := &http.Transport{}
+	registry = NewRegistry(RegistryConfig{Transport: shared})
+	account := cfg("http://proxy-a:3128")
+	account.Logger = slog.New(slog.NewTextHandler(&buf, nil))
+	a, err = registry.Register("a", account)
+	assert.NoError(t, err)
+	assert.Same(t, shared, a.transport)
+	assert.Contains(t, buf.String(), "level=WARN")
+	assert.Contains(t, buf.String(), "account=a")
+
+	buf.Reset()
+	account.Proxy = ""
+	_, err = registry.Register("b", account)
+	assert.NoError(t, err)
+	assert.Empty(t, buf.String())
+}
@@ -3,14 +3,24 @@ package swiftx
 import (
 	"log/slog"
 
-	"github.com/go-resty/resty/v2"
 	"github.com/hiscaler/swiftx-go/config"
 )
 
+// 接口名称
+const (
+	EndpointPingPong         = "pingPong"                        // 请求测试
+	EndpointCreateOrder      = "createOrderAndGetLabelPdfBase64" // 创建订单并获取面单
+	EndpointCancelOrder      = "cancelOrder"                     // 取消订单
+	EndpointBatchGetTracking = "batchGetTrackingInfo"            // 批量查询物流轨迹
+	EndpointBatchGetPrice    = "batchGetOrderPrice"              // 批量查询订单价格
+)
+
 type service struct {
-	config     *config.Config // Config
-	logger     *slog.Logger   // Logger
-	httpClient *resty.Client  // HTTP client
+	config  *config.Config // Config
+	logger  *slog.Logger   // Logger
+	handler Handler        // 调用链（中间件 + HTTP 请求）
+	limits  ServiceLimits  // 包裹重量和尺寸限制
+	dryRun  bool           // 是否为试运行模式
 }
 
 // API Services
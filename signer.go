@@ -0,0 +1,277 @@
+package swiftx
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// 签名请求头
+const (
+	HeaderAppKey        = "X-App-Key"
+	HeaderTimestamp     = "X-Timestamp"
+	HeaderNonce         = "X-Nonce"
+	HeaderContentSHA256 = "X-Content-SHA256"
+	HeaderSignature     = "X-Signature"
+)
+
+var (
+	ErrMissingSignature  = errors.New("缺少签名信息")
+	ErrInvalidSignature  = errors.New("无效签名")
+	ErrContentMismatch   = errors.New("请求内容与 X-Content-SHA256 不一致")
+	ErrTimestampExpired  = errors.New("请求时间戳超出允许的误差范围")
+	ErrNonceAlreadyUsed  = errors.New("重复的 Nonce")
+	errMissingCredential = errors.New("缺少签名凭证")
+)
+
+// Signature 签名信息
+type Signature struct {
+	AppKey        string // App Key
+	Timestamp     int64  // 时间戳（秒）
+	Nonce         string // 随机数
+	ContentSHA256 string // 请求内容的 SHA256 哈希值
+	Signature     string // 签名
+}
+
+// Signer 请求签名
+//
+// 签名格式：{app_key}\n{timestamp}\n{nonce}\n{content_sha256}\n{http_method}\n{path}\n{query_string}
+type Signer struct {
+	Clock func() time.Time       // 时钟，默认为 time.Now
+	Nonce func() (string, error) // 随机数生成器，默认为 16 字节随机数的十六进制编码
+}
+
+func (s Signer) now() time.Time {
+	if s.Clock != nil {
+		return s.Clock()
+	}
+	return time.Now()
+}
+
+func (s Signer) nonce() (string, error) {
+	if s.Nonce != nil {
+		return s.Nonce()
+	}
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("failed to generate random nonce: %w", err)
+	}
+	return hex.EncodeToString(b), nil
+}
+
+// Sign 使用凭证对请求签名并设置签名请求头
+//
+// 签名基于请求实际发送的内容（r.Body）、路径和查询字符串，读取后的请求内容会被还原。
+func (s Signer) Sign(r *http.Request, credential Credential) (Signature, error) {
+	if err := credential.validate(); err != nil {
+		return Signature{}, err
+	}
+	body, err := readBody(r)
+	if err != nil {
+		return Signature{}, err
+	}
+	nonce, err := s.nonce()
+	if err != nil {
+		return Signature{}, err
+	}
+
+	sign := Signature{
+		AppKey:        credential.AppKey,
+		Timestamp:     s.now().Unix(),
+		Nonce:         nonce,
+		ContentSHA256: contentSHA256(body),
+	}
+	sign.Signature = computeSignature(credential.AppSecret, sign, r.Method, r.URL.EscapedPath(), r.URL.RawQuery)
+
+	r.Header.Set(HeaderAppKey, sign.AppKey)
+	r.Header.Set(HeaderTimestamp, strconv.FormatInt(sign.Timestamp, 10))
+	r.Header.Set(HeaderNonce, sign.Nonce)
+	r.Header.Set(HeaderContentSHA256, sign.ContentSHA256)
+	r.Header.Set(HeaderSignature, sign.Signature)
+	return sign, nil
+}
+
+// Verifier 请求签名验证，用于回调接收方或测试服务端
+type Verifier struct {
+	MaxSkew time.Duration    // 允许的时间戳误差，默认为 5 分钟
+	Clock   func() time.Time // 时钟，默认为 time.Now
+	mu      sync.Mutex
+	nonces  map[string]time.Time // 已使用的 Nonce => 过期时间
+	purged  time.Time
+}
+
+// NewVerifier 创建签名验证器，maxSkew 为允许的时间戳误差
+func NewVerifier(maxSkew time.Duration) *Verifier {
+	return &Verifier{MaxSkew: maxSkew}
+}
+
+// Verify 验证请求签名、请求内容哈希值、时间戳误差，并拒绝在误差范围内重复使用的 Nonce
+func (v *Verifier) Verify(r *http.Request, secret string) error {
+	timestamp, err := strconv.ParseInt(r.Header.Get(HeaderTimestamp), 10, 64)
+	sign := Signature{
+		AppKey:        r.Header.Get(HeaderAppKey),
+		Timestamp:     timestamp,
+		Nonce:         r.Header.Get(HeaderNonce),
+		ContentSHA256: r.Header.Get(HeaderContentSHA256),
+		Signature:     r.Header.Get(HeaderSignature),
+	}
+	if err != nil || sign.AppKey == "" || sign.Nonce == "" || sign.ContentSHA256 == "" || sign.Signature == "" {
+		return ErrMissingSignature
+	}
+
+	body, err := readBody(r)
+	if err != nil {
+		return err
+	}
+	if !hmac.Equal([]byte(contentSHA256(body)), []byte(sign.ContentSHA256)) {
+		return ErrContentMismatch
+	}
+	expected := computeSignature(secret, sign, r.Method, r.URL.EscapedPath(), r.URL.RawQuery)
+	if !hmac.Equal([]byte(expected), []byte(sign.Signature)) {
+		return ErrInvalidSignature
+	}
+
+	maxSkew := v.MaxSkew
+	if maxSkew <= 0 {
+		maxSkew = 5 * time.Minute
+	}
+	now := time.Now()
+	if v.Clock != nil {
+		now = v.Clock()
+	}
+	t := time.Unix(sign.Timestamp, 0)
+	if skew := now.Sub(t); skew > maxSkew || skew < -maxSkew {
+		return fmt.Errorf("%w: %s", ErrTimestampExpired, skew.Truncate(time.Second))
+	}
+
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	if v.nonces == nil {
+		v.nonces = make(map[string]time.Time)
+	}
+	if now.Sub(v.purged) > maxSkew {
+		for nonce, expiresAt := range v.nonces {
+			if now.After(expiresAt) {
+				delete(v.nonces, nonce)
+			}
+		}
+		v.purged = now
+	}
+	key := sign.AppKey + "\n" + sign.Nonce
+	if expiresAt, ok := v.nonces[key]; ok && !now.After(expiresAt) {
+		return ErrNonceAlreadyUsed
+	}
+	v.nonces[key] = t.Add(maxSkew)
+	return nil
+}
+
+func computeSignature(secret string, sign Signature, method, path, query string) string {
+	stringToSign := fmt.Sprintf("%s\n%d\n%s\n%s\n%s\n%s\n%s",
+		sign.AppKey,
+		sign.Timestamp,
+		sign.Nonce,
+		sign.ContentSHA256,
+		method,
+		path,
+		query,
+	)
+	h := hmac.New(sha256.New, []byte(secret))
+	h.Write([]byte(stringToSign))
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+func contentSHA256(body []byte) string {
+	sum := sha256.Sum256(body)
+	return hex.EncodeToString(sum[:])
+}
+
+// readBody 读取请求内容并还原 r.Body
+func readBody(r *http.Request) ([]byte, error) {
+	if r.Body == nil || r.Body == http.NoBody {
+		return nil, nil
+	}
+	if r.GetBody != nil {
+		rc, err := r.GetBody()
+		if err != nil {
+			return nil, err
+		}
+		defer rc.Close()
+		return io.ReadAll(rc)
+	}
+
+	body, err := io.ReadAll(r.Body)
+	r.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	r.Body = io.NopCloser(bytes.NewReader(body))
+	r.GetBody = func() (io.ReadCloser, error) {
+		return io.NopCloser(bytes.NewReader(body)), nil
+	}
+	return body, nil
+}
+
+// signingTransport 在请求发送前使用上下文中的凭证签名，并根据响应的 Date 头校正签名时间
+type signingTransport struct {
+	signer     Signer
+	clock      *serverClock
+	compensate bool // 是否使用服务器时间误差校正签名时间
+	next       http.RoundTripper
+}
+
+func (t *signingTransport) RoundTrip(r *http.Request) (*http.Response, error) {
+	credential, ok := r.Context().Value(credentialContextKey{}).(Credential)
+	if !ok {
+		return nil, errMissingCredential
+	}
+	signer := t.signer
+	if t.compensate {
+		if skew := t.clock.skew(); skew != 0 {
+			now := signer.now
+			signer.Clock = func() time.Time { return now().Add(skew) }
+		}
+	}
+
+	// RoundTripper 不应修改原始请求
+	r = r.Clone(r.Context())
+	if _, err := signer.Sign(r, credential); err != nil {
+		return nil, err
+	}
+	if signed, ok := r.Context().Value(signedRequestContextKey{}).(*atomic.Pointer[http.Request]); ok {
+		signed.Store(r)
+	}
+	sent := time.Now()
+	resp, err := t.next.RoundTrip(r)
+	if err == nil {
+		t.clock.observe(resp.Header.Get("Date"), sent, time.Now())
+	}
+	return resp, err
+}
+
+type signedRequestContextKey struct{}
+
+// withSignedRequest 返回记录签名后请求的上下文，signingTransport 每次发送请求（包括重试）时更新，
+// 请求失败（没有响应）时也能取得实际发送的签名、时间戳和随机数
+func withSignedRequest(ctx context.Context) (context.Context, *atomic.Pointer[http.Request]) {
+	signed := new(atomic.Pointer[http.Request])
+	return context.WithValue(ctx, signedRequestContextKey{}, signed), signed
+}
+
+// signedRequest 返回上下文中最近一次签名后的请求，没有时返回 nil
+func signedRequest(ctx context.Context) *http.Request {
+	if signed, ok := ctx.Value(signedRequestContextKey{}).(*atomic.Pointer[http.Request]); ok {
+		return signed.Load()
+	}
+	return nil
+}
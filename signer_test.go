@@ -0,0 +1,99 @@
+package swiftx
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func fixedSigner(t time.Time, nonce string) Signer {
+	return Signer{
+		Clock: func() time.Time { return t },
+		Nonce: func() (string, error) { return nonce, nil },
+	}
+}
+
+func TestSigner_Sign(t *testing.T) {
+	body := `{"trackingNo":"SWX1"}`
+	r := httptest.NewRequest(http.MethodPost, "https://test.open.swiftx-express.com/api/v2/openapi/cancelOrder?b=2&a=1", strings.NewReader(body))
+	now := time.Unix(1760000000, 0)
+	sign, err := fixedSigner(now, "abc").Sign(r, Credential{AppKey: "key", AppSecret: "secret"})
+	assert.NoError(t, err)
+
+	sum := sha256.Sum256([]byte(body))
+	contentSHA256 := hex.EncodeToString(sum[:])
+	h := hmac.New(sha256.New, []byte("secret"))
+	h.Write([]byte("key\n1760000000\nabc\n" + contentSHA256 + "\nPOST\n/api/v2/openapi/cancelOrder\nb=2&a=1"))
+	assert.Equal(t, Signature{
+		AppKey:        "key",
+		Timestamp:     1760000000,
+		Nonce:         "abc",
+		ContentSHA256: contentSHA256,
+		Signature:     hex.EncodeToString(h.Sum(nil)),
+	}, sign)
+	assert.Equal(t, sign.Signature, r.Header.Get(HeaderSignature))
+	assert.Equal(t, "1760000000", r.Header.Get(HeaderTimestamp))
+
+	// 签名后请求内容仍可读取
+	b, err := readBody(r)
+	assert.NoError(t, err)
+	assert.Equal(t, body, string(b))
+
+	_, err = Signer{}.Sign(r, Credential{AppKey: "key"})
+	assert.Error(t, err)
+}
+
+func TestVerifier_Verify(t *testing.T) {
+	now := time.Unix(1760000000, 0)
+	newRequest := func(nonce, body string) *http.Request {
+		r := httptest.NewRequest(http.MethodPost, "/api/v2/openapi/cancelOrder", strings.NewReader(body))
+		_, err := fixedSigner(now, nonce).Sign(r, Credential{AppKey: "key", AppSecret: "secret"})
+		assert.NoError(t, err)
+		return r
+	}
+	v := &Verifier{MaxSkew: time.Minute, Clock: func() time.Time { return now }}
+
+	r := newRequest("n1", "{}")
+	assert.NoError(t, v.Verify(r, "secret"))
+	assert.True(t, errors.Is(v.Verify(r, "secret"), ErrNonceAlreadyUsed))
+	assert.True(t, errors.Is(v.Verify(newRequest("n2", "{}"), "other"), ErrInvalidSignature))
+
+	r = newRequest("n3", "{}")
+	r.Body = http.NoBody
+	r.GetBody = nil
+	assert.True(t, errors.Is(v.Verify(r, "secret"), ErrContentMismatch))
+
+	r = httptest.NewRequest(http.MethodGet, "/", nil)
+	assert.True(t, errors.Is(v.Verify(r, "secret"), ErrMissingSignature))
+
+	late := &Verifier{MaxSkew: time.Minute, Clock: func() time.Time { return now.Add(2 * time.Minute) }}
+	assert.True(t, errors.Is(late.Verify(newRequest("n4", "{}"), "secret"), ErrTimestampExpired))
+}
+
+func TestClient_SignWireBody(t *testing.T) {
+	verifier := NewVerifier(time.Minute)
+	var verifyErr error
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		verifyErr = verifier.Verify(r, "test-app-secret")
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `{"success":true}`)
+	}, WithSigner(fixedSigner(time.Now(), "fixed-nonce")))
+
+	ok, err := c.Services.Order.Cancel(ctx, "SWX1")
+	assert.NoError(t, err)
+	assert.True(t, ok)
+	assert.NoError(t, verifyErr)
+
+	// 重试时使用相同的 Nonce 会被拒绝
+	_, _ = c.Services.Order.Cancel(ctx, "SWX1")
+	assert.True(t, errors.Is(verifyErr, ErrNonceAlreadyUsed))
+}
@@ -0,0 +1,119 @@
+package swiftx
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"time"
+
+	"github.com/hiscaler/swiftx-go/config"
+)
+
+// WithTLSConfig 设置 TLS 配置（比如自定义 CA 证书池、客户端证书），Config.CACertFile 中的证书会追加到其中。
+// 使用 WithTransport 设置 HTTP Transport 时无效
+func WithTLSConfig(tlsConfig *tls.Config) Option {
+	return func(c *Client) {
+		c.tlsConfig = tlsConfig
+	}
+}
+
+// NewTransport 根据配置中的代理、TLS 和连接池设置创建 HTTP Transport，tlsConfig 可以为空
+//
+// 多个 Client 共用连接（比如 RegistryConfig.Transport）时，可以使用该函数创建 Transport 后传入。
+func NewTransport(cfg config.Config, tlsConfig *tls.Config) (*http.Transport, error) {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	if cfg.Proxy != "" {
+		proxy, err := url.Parse(cfg.Proxy)
+		if err != nil || proxy.Host == "" {
+			return nil, fmt.Errorf("swiftx: 无效的代理地址 %q", cfg.Proxy)
+		}
+		transport.Proxy = http.ProxyURL(proxy)
+	}
+
+	if tlsConfig != nil {
+		transport.TLSClientConfig = tlsConfig.Clone()
+	}
+	if cfg.CACertFile != "" {
+		pem, err := os.ReadFile(cfg.CACertFile)
+		if err != nil {
+			return nil, fmt.Errorf("swiftx: 读取 CA 证书文件失败：%w", err)
+		}
+		if transport.TLSClientConfig == nil {
+			transport.TLSClientConfig = &tls.Config{}
+		}
+		pool := transport.TLSClientConfig.RootCAs
+		if pool == nil {
+			if pool, err = x509.SystemCertPool(); err != nil {
+				pool = x509.NewCertPool()
+			}
+		} else {
+			pool = pool.Clone()
+		}
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("swiftx: CA 证书文件 %s 中没有有效的证书", cfg.CACertFile)
+		}
+		transport.TLSClientConfig.RootCAs = pool
+	}
+	if cfg.InsecureSkipVerify {
+		if transport.TLSClientConfig == nil {
+			transport.TLSClientConfig = &tls.Config{}
+		}
+		transport.TLSClientConfig.InsecureSkipVerify = true
+	}
+
+	transport.DisableKeepAlives = cfg.DisableKeepAlives
+	if cfg.MaxIdleConns > 0 {
+		transport.MaxIdleConns = cfg.MaxIdleConns
+	}
+	if cfg.MaxIdleConnsPerHost > 0 {
+		transport.MaxIdleConnsPerHost = cfg.MaxIdleConnsPerHost
+	}
+	if cfg.MaxConnsPerHost > 0 {
+		transport.MaxConnsPerHost = cfg.MaxConnsPerHost
+	}
+	if cfg.IdleConnTimeout > 0 {
+		transport.IdleConnTimeout = time.Duration(cfg.IdleConnTimeout) * time.Second
+	}
+	return transport, nil
+}
+
+// errTransport 无法根据配置创建 HTTP Transport 时使用，所有请求都返回创建失败的原因，
+// 避免在配置错误（比如代理地址无效）时绕过代理直接发送请求
+type errTransport struct {
+	err error
+}
+
+func (t errTransport) RoundTrip(r *http.Request) (*http.Response, error) {
+	if r.Body != nil {
+		_ = r.Body.Close()
+	}
+	return nil, t.err
+}
+
+// transportSettings 配置中影响 HTTP Transport 的设置，设置相同的配置可以共用同一个 Transport
+type transportSettings struct {
+	proxy               string
+	caCertFile          string
+	insecureSkipVerify  bool
+	disableKeepAlives   bool
+	maxIdleConns        int
+	maxIdleConnsPerHost int
+	maxConnsPerHost     int
+	idleConnTimeout     int
+}
+
+func transportSettingsOf(cfg config.Config) transportSettings {
+	return transportSettings{
+		proxy:               cfg.Proxy,
+		caCertFile:          cfg.CACertFile,
+		insecureSkipVerify:  cfg.InsecureSkipVerify,
+		disableKeepAlives:   cfg.DisableKeepAlives,
+		maxIdleConns:        cfg.MaxIdleConns,
+		maxIdleConnsPerHost: cfg.MaxIdleConnsPerHost,
+		maxConnsPerHost:     cfg.MaxConnsPerHost,
+		idleConnTimeout:     cfg.IdleConnTimeout,
+	}
+}